@@ -0,0 +1,152 @@
+package types
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// StringMap is standard string map, that implements Getter
+type StringMap map[string]string
+
+func (that StringMap) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if v, ok := that[name]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// Values is url values, that implements Getter.
+// Only the first value of the key is returned.
+// Example: Type.Integer.Get(ctx, Values(r.URL.Query()), "limit", 50)
+type Values url.Values
+
+func (that Values) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if vs, ok := that[name]; ok && len(vs) != 0 {
+		return vs[0], nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// Header is http header, that implements Getter.
+// Names are canonicalized and only the first value of the key is returned.
+// Example: Type.String.Get(ctx, Header(r.Header), "X-Request-Id", "")
+type Header http.Header
+
+func (that Header) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if vs, ok := that[http.CanonicalHeaderKey(name)]; ok && len(vs) != 0 {
+		return vs[0], nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+type envGetter struct {
+	prefix string
+}
+
+func (that *envGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if v, ok := os.LookupEnv(that.prefix + name); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// NewEnvGetter is constructor for creating getter of the os environment variables.
+// Prefix is added to the each property name.
+// Example: NewEnvGetter("APP_")
+func NewEnvGetter(prefix string) Getter {
+	return &envGetter{prefix: prefix}
+}
+
+// NewEnvironGetter is constructor for creating getter from the list of "key=value" strings
+// in the form of os.Environ.
+func NewEnvironGetter(environ []string) StringMap {
+	res := make(StringMap, len(environ))
+	for _, item := range environ {
+		if key, val, ok := strings.Cut(item, "="); ok {
+			res[key] = val
+		}
+	}
+	return res
+}
+
+type flagGetter struct {
+	flags *flag.FlagSet
+}
+
+func (that *flagGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	var res interface{}
+	that.flags.Visit(func(f *flag.Flag) {
+		if f.Name != name {
+			return
+		}
+		if g, ok := f.Value.(flag.Getter); ok {
+			res = g.Get()
+		} else {
+			res = f.Value.String()
+		}
+	})
+	if res != nil {
+		return res, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// NewFlagGetter is constructor for creating getter of the flag set.
+// Only flags, that have been set, are matched, so default values
+// of the flags do not hide values of another getters (see Layered).
+func NewFlagGetter(flags *flag.FlagSet) Getter {
+	return &flagGetter{flags: flags}
+}
+
+// RowScanner is abstract cursor of the rows (see sql.Rows).
+type RowScanner interface {
+	Columns() ([]string, error)
+	Scan(dest ...interface{}) error
+}
+
+type rowGetter map[string]interface{}
+
+func (that rowGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if v, ok := that[name]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// NewRowGetter is constructor for creating getter of the current row of the cursor.
+// Values are converted by typers with semantics of convert.ConvertAssign.
+// Example:
+//
+//	for rows.Next() {
+//		row, err := NewRowGetter(rows)
+//		...
+//		id, err := Type.Integer.Get(ctx, row, "id", 0)
+//	}
+func NewRowGetter(rows RowScanner) (Getter, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("Columns: %w", err)
+	}
+
+	values := make([]interface{}, len(columns))
+	refs := make([]interface{}, len(columns))
+	for i := range values {
+		refs[i] = &values[i]
+	}
+
+	err = rows.Scan(refs...)
+	if err != nil {
+		return nil, fmt.Errorf("Scan: %w", err)
+	}
+
+	res := make(rowGetter, len(columns))
+	for i, column := range columns {
+		res[column] = values[i]
+	}
+	return res, nil
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"context"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type testRows struct {
+	columns []string
+	values  []interface{}
+}
+
+func (that *testRows) Columns() ([]string, error) {
+	return that.columns, nil
+}
+
+func (that *testRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		*d.(*interface{}) = that.values[i]
+	}
+	return nil
+}
+
+func TestAdapters(t *testing.T) {
+	ctx := context.Background()
+
+	query, err := url.ParseQuery("limit=20&limit=30")
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("X-Limit", "40")
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int("limit", 100, "")
+	flags.Duration("timeout", time.Second, "")
+	require.NoError(t, flags.Parse([]string{"-limit", "60"}))
+
+	row, err := NewRowGetter(&testRows{
+		columns: []string{"limit"},
+		values:  []interface{}{[]byte("70")},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		getter Getter
+		name   string
+		want   int64
+	}{
+		"map":     {getter: StringMap{"limit": "10"}, name: "limit", want: 10},
+		"values":  {getter: Values(query), name: "limit", want: 20},
+		"header":  {getter: Header(header), name: "x-limit", want: 40},
+		"environ": {getter: NewEnvironGetter([]string{"LIMIT=50"}), name: "LIMIT", want: 50},
+		"flags":   {getter: NewFlagGetter(flags), name: "limit", want: 60},
+		"row":     {getter: row, name: "limit", want: 70},
+		"missing": {getter: NewFlagGetter(flags), name: "timeout", want: 5},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := Type.Integer.Get(ctx, test.getter, test.name, 5)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, res)
+		})
+	}
+}
+
+func TestEnvGetter(t *testing.T) {
+	t.Setenv("TEST_LIMIT", "80")
+	res, err := Type.Integer.Get(context.Background(), NewEnvGetter("TEST_"), "LIMIT", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(80), res)
+}
@@ -0,0 +1,233 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/adverax/core"
+	"github.com/adverax/types/ranges"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Constraint is abstract restriction of the typed value.
+// String returns human readable description of the constraint,
+// that can be used for documentation or schema generation.
+type Constraint[T any] interface {
+	Check(value T) error
+	String() string
+}
+
+// ConstraintError is returned when value does not satisfy constraint.
+type ConstraintError struct {
+	Value      interface{}
+	Constraint string
+}
+
+func (that *ConstraintError) Error() string {
+	return fmt.Sprintf("value %v does not satisfy constraint %s", that.Value, that.Constraint)
+}
+
+// RangeConstraint restricts value by range (closed, half-open or unbounded).
+type RangeConstraint[T core.Ordered] struct {
+	Range ranges.Range[T]
+}
+
+func (that *RangeConstraint[T]) Check(value T) error {
+	if that.Range.Contains(value) {
+		return nil
+	}
+	return &ConstraintError{Value: value, Constraint: that.String()}
+}
+
+func (that *RangeConstraint[T]) String() string {
+	r := that.Range
+	if r.MinBound == ranges.Inclusive && r.MaxBound == ranges.Inclusive {
+		return fmt.Sprintf("range [%v, %v]", r.Min, r.Max)
+	}
+	return fmt.Sprintf("range %s", r)
+}
+
+// EnumConstraint restricts value by the list of allowed values.
+type EnumConstraint[T comparable] struct {
+	Values []T
+}
+
+func (that *EnumConstraint[T]) Check(value T) error {
+	for _, v := range that.Values {
+		if v == value {
+			return nil
+		}
+	}
+	return &ConstraintError{Value: value, Constraint: that.String()}
+}
+
+func (that *EnumConstraint[T]) String() string {
+	list := make([]string, len(that.Values))
+	for i, v := range that.Values {
+		list[i] = fmt.Sprintf("%v", v)
+	}
+	return fmt.Sprintf("one of [%s]", strings.Join(list, ", "))
+}
+
+// PatternConstraint restricts string value by regular expression.
+type PatternConstraint struct {
+	Pattern *regexp.Regexp
+}
+
+func (that *PatternConstraint) Check(value string) error {
+	if that.Pattern.MatchString(value) {
+		return nil
+	}
+	return &ConstraintError{Value: value, Constraint: that.String()}
+}
+
+func (that *PatternConstraint) String() string {
+	return fmt.Sprintf("pattern %q", that.Pattern.String())
+}
+
+// NonEmptyConstraint restricts value by non-zero values.
+type NonEmptyConstraint[T any] struct{}
+
+func (that *NonEmptyConstraint[T]) Check(value T) error {
+	if reflect.ValueOf(&value).Elem().IsZero() {
+		return &ConstraintError{Value: value, Constraint: that.String()}
+	}
+	return nil
+}
+
+func (that *NonEmptyConstraint[T]) String() string {
+	return "non empty"
+}
+
+// ConstrainedType is typer, that validates values of the base typer by constraints.
+type ConstrainedType[T any] struct {
+	base        Typer[T]
+	constraints []Constraint[T]
+}
+
+// Base returns underlying typer.
+func (that *ConstrainedType[T]) Base() Typer[T] {
+	return that.base
+}
+
+// Constraints returns list of constraints for documentation purposes.
+func (that *ConstrainedType[T]) Constraints() []Constraint[T] {
+	return that.constraints
+}
+
+// Validate checks value by all constraints.
+func (that *ConstrainedType[T]) Validate(value T) error {
+	for _, constraint := range that.constraints {
+		if err := constraint.Check(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns description of all constraints.
+func (that *ConstrainedType[T]) String() string {
+	list := make([]string, len(that.constraints))
+	for i, constraint := range that.constraints {
+		list[i] = constraint.String()
+	}
+	return strings.Join(list, " and ")
+}
+
+func (that *ConstrainedType[T]) Is(value interface{}) bool {
+	if !that.base.Is(value) {
+		return false
+	}
+	_, ok := that.TryCast(value)
+	return ok
+}
+
+func (that *ConstrainedType[T]) Get(ctx context.Context, getter Getter, name string, defVal T) (res T, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, GetErrNoMatch()) {
+			return defVal, nil
+		}
+		return
+	}
+	if val == nil {
+		return defVal, nil
+	}
+	res, ok := that.base.TryCast(val)
+	if !ok {
+		return res, fmt.Errorf("can not convert value %v with key %q", val, name)
+	}
+	if err := that.Validate(res); err != nil {
+		return res, fmt.Errorf("invalid value with key %q: %w", name, err)
+	}
+	return res, nil
+}
+
+func (that *ConstrainedType[T]) Require(ctx context.Context, getter Getter, name string) (res T, err error) {
+	res, err = Require(ctx, that.base, getter, name)
+	if err != nil {
+		return
+	}
+	if err := that.Validate(res); err != nil {
+		return res, fmt.Errorf("invalid value with key %q: %w", name, err)
+	}
+	return res, nil
+}
+
+func (that *ConstrainedType[T]) TryCast(value interface{}) (res T, ok bool) {
+	res, ok = that.base.TryCast(value)
+	if !ok {
+		return
+	}
+	if that.Validate(res) != nil {
+		var zero T
+		return zero, false
+	}
+	return res, true
+}
+
+func (that *ConstrainedType[T]) Cast(value interface{}, defVal T) T {
+	if vv, ok := that.TryCast(value); ok {
+		return vv
+	}
+	return defVal
+}
+
+// Validated is constructor for creating typer with custom constraints.
+// If base typer is constrained already, constraints are appended to its own.
+// Example: Validated(Type.String, &NonEmptyConstraint[string]{})
+func Validated[T any](base Typer[T], constraints ...Constraint[T]) *ConstrainedType[T] {
+	if b, ok := base.(*ConstrainedType[T]); ok {
+		list := make([]Constraint[T], 0, len(b.constraints)+len(constraints))
+		list = append(list, b.constraints...)
+		list = append(list, constraints...)
+		return &ConstrainedType[T]{base: b.base, constraints: list}
+	}
+	return &ConstrainedType[T]{base: base, constraints: constraints}
+}
+
+// Constrain is constructor for creating typer, that restricts values by range.
+// Example: Constrain(Type.Integer, ranges.NewRange[int64](1, 65535))
+func Constrain[T core.Ordered](base Typer[T], r ranges.Range[T]) *ConstrainedType[T] {
+	return Validated[T](base, &RangeConstraint[T]{Range: r})
+}
+
+// OneOf is constructor for creating typer, that restricts values by enumeration.
+// Example: OneOf(Type.String, "debug", "info", "warn")
+func OneOf[T comparable](base Typer[T], values ...T) *ConstrainedType[T] {
+	return Validated[T](base, &EnumConstraint[T]{Values: values})
+}
+
+// Pattern is constructor for creating typer, that restricts strings by regular expression.
+// Example: Pattern(Type.String, regexp.MustCompile(`^[a-z]+$`))
+func Pattern(base Typer[string], pattern *regexp.Regexp) *ConstrainedType[string] {
+	return Validated[string](base, &PatternConstraint{Pattern: pattern})
+}
+
+// NonEmpty is constructor for creating typer, that rejects zero values.
+// Example: NonEmpty(Type.String)
+func NonEmpty[T any](base Typer[T]) *ConstrainedType[T] {
+	return Validated[T](base, &NonEmptyConstraint[T]{})
+}
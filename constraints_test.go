@@ -0,0 +1,60 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"github.com/adverax/types/ranges"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+type testGetter map[string]interface{}
+
+func (that testGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if v, ok := that[name]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+func TestConstrain(t *testing.T) {
+	ctx := context.Background()
+	port := Constrain(Type.Integer, ranges.NewRange[int64](1, 65535))
+	getter := testGetter{"good": "8080", "bad": 70000}
+
+	res, err := port.Get(ctx, getter, "good", 80)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8080), res)
+
+	res, err = port.Get(ctx, getter, "missing", 80)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(80), res)
+
+	_, err = port.Get(ctx, getter, "bad", 80)
+	var constraintErr *ConstraintError
+	assert.ErrorAs(t, err, &constraintErr)
+	assert.Equal(t, "range [1, 65535]", constraintErr.Constraint)
+	assert.Equal(t, "range [1,10)", (&RangeConstraint[int]{Range: ranges.NewHalfOpenRange(1, 10)}).String())
+
+	assert.True(t, port.Is(int64(22)))
+	assert.False(t, port.Is(int64(0)))
+	assert.Equal(t, int64(1), port.Cast(70000, 1))
+}
+
+func TestOneOf(t *testing.T) {
+	level := OneOf(Type.String, "debug", "info", "warn")
+	_, ok := level.TryCast("info")
+	assert.True(t, ok)
+	_, ok = level.TryCast("trace")
+	assert.False(t, ok)
+	assert.Equal(t, "one of [debug, info, warn]", level.String())
+}
+
+func TestPatternAndNonEmpty(t *testing.T) {
+	name := NonEmpty(Pattern(Type.String, regexp.MustCompile(`^[a-z]*$`)))
+	assert.Len(t, name.Constraints(), 2)
+	assert.NoError(t, name.Validate("abc"))
+	assert.Error(t, name.Validate(""))
+	assert.Error(t, name.Validate("ABC"))
+}
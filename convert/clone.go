@@ -36,20 +36,17 @@ func CloneValueTo(dst interface{}, src interface{}) {
 	starY.Set(x)
 }
 
+// CloneValue returns deep copy of value (see DeepCopy).
+// If value is pointer, copy of the pointed value is returned.
 func CloneValue(src interface{}) interface{} {
 	x := reflect.ValueOf(src)
 	if x.Kind() == reflect.Ptr {
-		starX := x.Elem()
-		y := reflect.New(starX.Type())
-		starY := y.Elem()
-		starY.Set(starX)
-		return starY.Interface()
-	} else {
-		if src == nil {
-			return nil
+		if x.IsNil() {
+			return reflect.Zero(x.Type().Elem()).Interface()
 		}
-		return x.Interface()
+		return DeepCopy(x.Elem().Interface())
 	}
+	return DeepCopy(src)
 }
 
 func MakePointerTo(obj interface{}) interface{} {
@@ -3,6 +3,7 @@ package convert
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestCloneValue(t *testing.T) {
@@ -49,3 +50,61 @@ func TestCloneValueTo(t *testing.T) {
 	src.c = append(src.c, "c")
 	assert.NotEqual(t, src, dst)
 }
+
+type deepCopyNode struct {
+	Name     string
+	Tags     []string
+	Attrs    map[string]interface{}
+	Next     *deepCopyNode
+	Created  time.Time
+	Any      interface{}
+	Matrix   [2][]int
+	internal []int
+}
+
+type deepCopyCustom struct {
+	Value int
+}
+
+func (that *deepCopyCustom) DeepCopy() interface{} {
+	return &deepCopyCustom{Value: that.Value + 1}
+}
+
+func TestDeepCopy(t *testing.T) {
+	shared := []string{"a", "b"}
+	src := &deepCopyNode{
+		Name:     "root",
+		Tags:     shared,
+		Attrs:    map[string]interface{}{"list": []interface{}{1, map[string]interface{}{"x": 1}}},
+		Created:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Any:      shared,
+		Matrix:   [2][]int{{1}, {2}},
+		internal: []int{1},
+	}
+	src.Next = src
+
+	dst := DeepCopy(src).(*deepCopyNode)
+	assert.Equal(t, src.Name, dst.Name)
+	assert.Equal(t, src.Created, dst.Created)
+	assert.Same(t, dst, dst.Next)
+
+	dst.Tags[0] = "z"
+	assert.Equal(t, "a", src.Tags[0])
+	assert.Equal(t, "z", dst.Any.([]string)[0], "aliases must be preserved")
+
+	dst.Attrs["list"].([]interface{})[1].(map[string]interface{})["x"] = 2
+	assert.Equal(t, 1, src.Attrs["list"].([]interface{})[1].(map[string]interface{})["x"])
+
+	dst.Matrix[0][0] = 9
+	assert.Equal(t, 1, src.Matrix[0][0])
+
+	dst.internal[0] = 9
+	assert.Equal(t, 9, src.internal[0], "unexported fields are shared by default")
+
+	dst = DeepCopyWith(src, DeepCopyOptions{CopyUnexported: true}).(*deepCopyNode)
+	dst.internal[0] = 7
+	assert.Equal(t, 9, src.internal[0])
+
+	custom := DeepCopy([]*deepCopyCustom{{Value: 1}}).([]*deepCopyCustom)
+	assert.Equal(t, 2, custom[0].Value)
+}
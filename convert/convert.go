@@ -21,6 +21,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/adverax/types/natural"
+	"math"
 	"reflect"
 	"strconv"
 	"time"
@@ -931,22 +933,155 @@ func ConvertToJson(val interface{}) (res json.RawMessage, valid bool) {
 	}
 }
 
-func IsEqualMaps(a, b map[string]interface{}) bool {
-	if len(a) != len(b) {
-		return false
+// ConvertToNatural converts value into natural fraction.
+// Strings are parsed by natural.Parse, floats are converted exactly
+// by their shortest decimal representation.
+func ConvertToNatural(val interface{}) (res natural.Value, valid bool) {
+	switch v := val.(type) {
+	case natural.Value:
+		return v.Simplify(), !v.IsNaN()
+	case *natural.Value:
+		if v == nil {
+			return natural.NaN, false
+		}
+		return v.Simplify(), !v.IsNaN()
+	case int8:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case int16:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case int32:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case int64:
+		return natural.Value{Num: v, Div: 1}, true
+	case uint8:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case uint16:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case uint32:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case uint64:
+		if v > math.MaxInt64 {
+			return natural.NaN, false
+		}
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case int:
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return natural.NaN, false
+		}
+		return natural.Value{Num: int64(v), Div: 1}, true
+	case float32:
+		return parseNatural(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case float64:
+		return parseNatural(strconv.FormatFloat(v, 'g', -1, 64))
+	case string:
+		return parseNatural(v)
+	case []byte:
+		return parseNatural(string(v))
+	case json.Number:
+		return parseNatural(string(v))
+	case json.RawMessage:
+		err := jsonUnmarshal(v, &res)
+		return res, err == nil && !res.IsNaN()
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String {
+			return naturalFromMap(rv)
+		}
+		err := ConvertAssign(&res, v)
+		if err != nil {
+			return natural.NaN, false
+		}
+		return res, !res.IsNaN()
 	}
+}
 
-	for key, val := range a {
-		if v, has := b[key]; has {
-			if v != val {
-				return false
-			}
-		} else {
-			return false
+// naturalFromMap converts object form {"num": 3, "div": 4} into natural fraction.
+func naturalFromMap(m reflect.Value) (natural.Value, bool) {
+	num := m.MapIndex(reflect.ValueOf("num").Convert(m.Type().Key()))
+	div := m.MapIndex(reflect.ValueOf("div").Convert(m.Type().Key()))
+	if !num.IsValid() || !div.IsValid() {
+		return natural.NaN, false
+	}
+	n, ok1 := ConvertToInt64(num.Interface())
+	d, ok2 := ConvertToInt64(div.Interface())
+	if !ok1 || !ok2 {
+		return natural.NaN, false
+	}
+	res, err := natural.New(n, d)
+	return res, err == nil
+}
+
+func parseNatural(s string) (natural.Value, bool) {
+	res, err := natural.Parse(s)
+	if err != nil {
+		return natural.NaN, false
+	}
+	return res, !res.IsNaN()
+}
+
+// ConvertToDecimal converts value into exact decimal.
+// Strings and json.Number are parsed without rounding through float64,
+// floats are converted by their shortest decimal representation.
+func ConvertToDecimal(val interface{}) (res natural.Decimal, valid bool) {
+	switch v := val.(type) {
+	case natural.Decimal:
+		return v, true
+	case *natural.Decimal:
+		if v == nil {
+			return res, false
+		}
+		return *v, true
+	case int8:
+		return natural.NewDecimal(int64(v), 0), true
+	case int16:
+		return natural.NewDecimal(int64(v), 0), true
+	case int32:
+		return natural.NewDecimal(int64(v), 0), true
+	case int64:
+		return natural.NewDecimal(v, 0), true
+	case uint8:
+		return natural.NewDecimal(int64(v), 0), true
+	case uint16:
+		return natural.NewDecimal(int64(v), 0), true
+	case uint32:
+		return natural.NewDecimal(int64(v), 0), true
+	case uint64:
+		return parseDecimal(strconv.FormatUint(v, 10))
+	case int:
+		return natural.NewDecimal(int64(v), 0), true
+	case uint:
+		return parseDecimal(strconv.FormatUint(uint64(v), 10))
+	case float32:
+		return parseDecimal(strconv.FormatFloat(float64(v), 'f', -1, 32))
+	case float64:
+		return parseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+	case string:
+		return parseDecimal(v)
+	case []byte:
+		return parseDecimal(string(v))
+	case json.Number:
+		return parseDecimal(string(v))
+	case json.RawMessage:
+		err := jsonUnmarshal(v, &res)
+		return res, err == nil
+	default:
+		err := ConvertAssign(&res, v)
+		if err != nil {
+			return res, false
 		}
+		return res, true
 	}
+}
+
+func parseDecimal(s string) (natural.Decimal, bool) {
+	res, err := natural.ParseDecimal(s)
+	return res, err == nil
+}
 
-	return true
+// IsEqualMaps checks if maps are deeply equal (see DeepEqual).
+func IsEqualMaps(a, b map[string]interface{}) bool {
+	return DeepEqual(a, b, EqualOptions{})
 }
 
 func jsonUnmarshal(data json.RawMessage, value interface{}) error {
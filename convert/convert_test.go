@@ -1,6 +1,8 @@
 package convert
 
 import (
+	"encoding/json"
+	"github.com/adverax/types/natural"
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
@@ -1223,9 +1225,46 @@ func TestConvertTo(t *testing.T) {
 			dst: true,
 			ok:  true,
 		},
+		// ConvertToNatural
+		"Convert int to natural must be success": {
+			src: int(3),
+			dst: natural.Value{Num: 3, Div: 1},
+			ok:  true,
+		},
+		"Convert float64 to natural must be success": {
+			src: float64(0.1),
+			dst: natural.Value{Num: 1, Div: 10},
+			ok:  true,
+		},
+		"Convert string to natural must be success": {
+			src: "-1 1/2",
+			dst: natural.Value{Num: -3, Div: 2},
+			ok:  true,
+		},
+		"Convert json.Number to natural must be success": {
+			src: json.Number("0.75"),
+			dst: natural.Value{Num: 3, Div: 4},
+			ok:  true,
+		},
+		"Convert json.RawMessage to natural must be success": {
+			src: json.RawMessage(`{"num":2,"div":4}`),
+			dst: natural.Value{Num: 1, Div: 2},
+			ok:  true,
+		},
+		"Convert map to natural must be success": {
+			src: map[string]interface{}{"num": json.Number("2"), "div": json.Number("-4")},
+			dst: natural.Value{Num: -1, Div: 2},
+			ok:  true,
+		},
+		"Convert invalid string to natural must be failed": {
+			src: "1/0",
+			dst: natural.NaN,
+			ok:  false,
+		},
 	}
 
 	fs := map[string]interface{}{
+		"Value":   ConvertToNatural,
 		"int":     ConvertToInt,
 		"int8":    ConvertToInt8,
 		"int16":   ConvertToInt16,
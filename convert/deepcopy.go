@@ -0,0 +1,195 @@
+package convert
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// DeepCopier is implemented by types, that know how to copy themselves.
+// DeepCopy must return value of the same type.
+type DeepCopier interface {
+	DeepCopy() interface{}
+}
+
+// DeepCopyOptions is options of the deep copy.
+type DeepCopyOptions struct {
+	// CopyUnexported enables deep copy of unexported struct fields.
+	// Otherwise unexported fields are copied by assignment.
+	CopyUnexported bool
+}
+
+// DeepCopy returns deep copy of value.
+// Pointers, maps and slices, that refer to the same data, remain aliases in the copy,
+// so cyclic structures are supported.
+// Example: dst := DeepCopy(src).(Config)
+func DeepCopy(src interface{}) interface{} {
+	return DeepCopyWith(src, DeepCopyOptions{})
+}
+
+// DeepCopyWith returns deep copy of value with custom options.
+func DeepCopyWith(src interface{}, options DeepCopyOptions) interface{} {
+	if src == nil {
+		return nil
+	}
+	c := &deepCopier{
+		options: options,
+		visited: make(map[visitKey]reflect.Value),
+	}
+	return c.copy(reflect.ValueOf(src)).Interface()
+}
+
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+	cap int
+}
+
+type deepCopier struct {
+	options DeepCopyOptions
+	visited map[visitKey]reflect.Value
+}
+
+func (that *deepCopier) copy(src reflect.Value) reflect.Value {
+	if !src.IsValid() {
+		return src
+	}
+
+	if src.Kind() != reflect.Interface && src.CanInterface() && src.Type().Implements(deepCopierType) {
+		if src.Kind() == reflect.Ptr && src.IsNil() {
+			return src
+		}
+		res := reflect.ValueOf(src.Interface().(DeepCopier).DeepCopy())
+		if !res.IsValid() {
+			return reflect.Zero(src.Type())
+		}
+		if dst, ok := that.copied(src, res); ok {
+			return dst
+		}
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return src
+		}
+		key := visitKey{ptr: src.Pointer(), typ: src.Type()}
+		if dst, ok := that.visited[key]; ok {
+			return dst
+		}
+		dst := reflect.New(src.Type().Elem())
+		that.visited[key] = dst
+		dst.Elem().Set(that.copy(src.Elem()))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return src
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(that.copy(src.Elem()))
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return src
+		}
+		key := visitKey{ptr: src.Pointer(), typ: src.Type()}
+		if dst, ok := that.visited[key]; ok {
+			return dst
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		that.visited[key] = dst
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(that.copy(iter.Key()), that.copy(iter.Value()))
+		}
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return src
+		}
+		key := visitKey{ptr: src.Pointer(), typ: src.Type(), len: src.Len(), cap: src.Cap()}
+		if dst, ok := that.visited[key]; ok {
+			return dst
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		that.visited[key] = dst
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(that.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(that.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Struct:
+		if src.Type() == timeType {
+			return src
+		}
+		return that.copyStruct(src)
+
+	default:
+		return src
+	}
+}
+
+// copied adapts result of the DeepCopier to the type of src.
+// Pointer receives new pointer to the result, if DeepCopier is implemented by the element type.
+// It reports false, if result is not compatible with src, so src must be copied by reflection.
+func (that *deepCopier) copied(src, res reflect.Value) (reflect.Value, bool) {
+	tp := src.Type()
+	if res.Type().AssignableTo(tp) {
+		dst := reflect.New(tp).Elem()
+		dst.Set(res)
+		return dst, true
+	}
+	if tp.Kind() == reflect.Ptr && res.Type().AssignableTo(tp.Elem()) {
+		key := visitKey{ptr: src.Pointer(), typ: tp}
+		if dst, ok := that.visited[key]; ok {
+			return dst, true
+		}
+		dst := reflect.New(tp.Elem())
+		dst.Elem().Set(res)
+		that.visited[key] = dst
+		return dst, true
+	}
+	return reflect.Value{}, false
+}
+
+func (that *deepCopier) copyStruct(src reflect.Value) reflect.Value {
+	tp := src.Type()
+	dst := reflect.New(tp).Elem()
+	dst.Set(src)
+	if !src.CanAddr() {
+		src = dst
+		dst = reflect.New(tp).Elem()
+		dst.Set(src)
+	}
+
+	for i := 0; i < tp.NumField(); i++ {
+		sf := src.Field(i)
+		df := dst.Field(i)
+		if !tp.Field(i).IsExported() {
+			if !that.options.CopyUnexported {
+				continue
+			}
+			sf = reflect.NewAt(sf.Type(), unsafe.Pointer(sf.UnsafeAddr())).Elem()
+			df = reflect.NewAt(df.Type(), unsafe.Pointer(df.UnsafeAddr())).Elem()
+		}
+		df.Set(that.copy(sf))
+	}
+
+	return dst
+}
+
+var (
+	deepCopierType = reflect.TypeOf((*DeepCopier)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+)
@@ -0,0 +1,362 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// EqualOptions is options of the deep comparison.
+type EqualOptions struct {
+	// NormalizeNumbers enables comparison of numbers by value regardless of their types
+	// (json.Number, signed and unsigned integers and floats).
+	NormalizeNumbers bool
+	// Epsilon is tolerance for comparison of the floats.
+	Epsilon float64
+	// NilAsEmpty treats nil maps and slices as equal to empty ones.
+	NilAsEmpty bool
+}
+
+// Difference describes single mismatch found by Diff.
+// Path is written as "$.key[index]", root is "$".
+type Difference struct {
+	Path  string
+	Left  interface{}
+	Right interface{}
+}
+
+func (that Difference) String() string {
+	return fmt.Sprintf("%s: %v != %v", that.Path, that.Left, that.Right)
+}
+
+// DeepEqual checks if values are structurally equal.
+// Unlike reflect.DeepEqual, maps and slices of different types are compared by content,
+// so Map and map[string]interface{} or []Map and []interface{} can be equal.
+func DeepEqual(a, b interface{}, options EqualOptions) bool {
+	c := &comparator{options: options, firstOnly: true, visited: make(map[visit]bool)}
+	c.compare("$", reflect.ValueOf(a), reflect.ValueOf(b))
+	return len(c.diffs) == 0
+}
+
+// Diff returns list of differences between values (see DeepEqual).
+func Diff(a, b interface{}, options EqualOptions) []Difference {
+	c := &comparator{options: options, visited: make(map[visit]bool)}
+	c.compare("$", reflect.ValueOf(a), reflect.ValueOf(b))
+	return c.diffs
+}
+
+type comparator struct {
+	options   EqualOptions
+	firstOnly bool
+	diffs     []Difference
+	visited   map[visit]bool
+}
+
+// visit is pair of the compared references, it is used for detection of cycles.
+type visit struct {
+	a, b   uintptr
+	ta, tb reflect.Type
+	len    int
+}
+
+func (that *comparator) compare(path string, a, b reflect.Value) {
+	if that.firstOnly && len(that.diffs) != 0 {
+		return
+	}
+
+	a = indirectValue(a)
+	b = indirectValue(b)
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() == b.IsValid() {
+			return
+		}
+		if that.options.NilAsEmpty && (isEmptyValue(a) || isEmptyValue(b)) {
+			return
+		}
+		that.report(path, a, b)
+		return
+	}
+
+	if isNumberValue(a) && isNumberValue(b) {
+		if !that.isEqualNumbers(a, b) {
+			that.report(path, a, b)
+		}
+		return
+	}
+
+	if that.isVisited(a, b) {
+		return
+	}
+
+	switch {
+	case a.Kind() == reflect.Map && b.Kind() == reflect.Map:
+		that.compareMaps(path, a, b)
+		return
+	case isListValue(a) && isListValue(b):
+		that.compareLists(path, a, b)
+		return
+	}
+
+	if a.Type() != b.Type() {
+		that.report(path, a, b)
+		return
+	}
+
+	if a.Kind() == reflect.Struct {
+		that.compareStructs(path, a, b)
+		return
+	}
+
+	if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+		that.report(path, a, b)
+	}
+}
+
+// isVisited checks if references are already compared (or being compared) and marks them.
+// Like reflect.DeepEqual, pair in progress is considered equal, so cyclic values are supported.
+func (that *comparator) isVisited(a, b reflect.Value) bool {
+	var key visit
+	switch {
+	case a.Kind() == reflect.Map && b.Kind() == reflect.Map,
+		a.Kind() == reflect.Slice && b.Kind() == reflect.Slice:
+		if a.Pointer() == 0 || b.Pointer() == 0 {
+			return false
+		}
+		key = visit{a: a.Pointer(), b: b.Pointer(), ta: a.Type(), tb: b.Type(), len: a.Len()}
+	case (a.Kind() == reflect.Struct || a.Kind() == reflect.Array) &&
+		a.CanAddr() && b.CanAddr() && a.Type() == b.Type():
+		key = visit{a: a.UnsafeAddr(), b: b.UnsafeAddr(), ta: a.Type(), tb: b.Type()}
+	default:
+		return false
+	}
+	if that.visited[key] {
+		return true
+	}
+	that.visited[key] = true
+	return false
+}
+
+func (that *comparator) compareMaps(path string, a, b reflect.Value) {
+	if a.IsNil() != b.IsNil() && !that.options.NilAsEmpty {
+		that.report(path, a, b)
+		return
+	}
+
+	keys := make(map[interface{}]reflect.Value, a.Len()+b.Len())
+	var order []interface{}
+	for _, m := range []reflect.Value{a, b} {
+		for _, k := range m.MapKeys() {
+			kk := mapKeyOf(k)
+			if _, ok := keys[kk]; !ok {
+				keys[kk] = k
+				order = append(order, kk)
+			}
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return fmt.Sprint(order[i]) < fmt.Sprint(order[j])
+	})
+
+	for _, kk := range order {
+		k := keys[kk]
+		p := fmt.Sprintf("%s.%v", path, kk)
+		av := lookupMapValue(a, k)
+		bv := lookupMapValue(b, k)
+		if !av.IsValid() || !bv.IsValid() {
+			if av.IsValid() != bv.IsValid() {
+				that.report(p, av, bv)
+			}
+			continue
+		}
+		that.compare(p, av, bv)
+	}
+}
+
+func (that *comparator) compareLists(path string, a, b reflect.Value) {
+	if a.Kind() == reflect.Slice && b.Kind() == reflect.Slice && a.IsNil() != b.IsNil() && !that.options.NilAsEmpty {
+		that.report(path, a, b)
+		return
+	}
+
+	n := a.Len()
+	if b.Len() > n {
+		n = b.Len()
+	}
+	for i := 0; i < n; i++ {
+		p := fmt.Sprintf("%s[%d]", path, i)
+		if i >= a.Len() || i >= b.Len() {
+			var av, bv reflect.Value
+			if i < a.Len() {
+				av = a.Index(i)
+			}
+			if i < b.Len() {
+				bv = b.Index(i)
+			}
+			that.report(p, av, bv)
+			continue
+		}
+		that.compare(p, a.Index(i), b.Index(i))
+	}
+}
+
+func (that *comparator) compareStructs(path string, a, b reflect.Value) {
+	tp := a.Type()
+	if tp == timeType {
+		if !a.Interface().(time.Time).Equal(b.Interface().(time.Time)) {
+			that.report(path, a, b)
+		}
+		return
+	}
+
+	for i := 0; i < tp.NumField(); i++ {
+		if !tp.Field(i).IsExported() {
+			// Unexported fields are not accessible, so compare the whole structure.
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				that.report(path, a, b)
+			}
+			return
+		}
+	}
+
+	for i := 0; i < tp.NumField(); i++ {
+		that.compare(path+"."+tp.Field(i).Name, a.Field(i), b.Field(i))
+	}
+}
+
+func (that *comparator) isEqualNumbers(a, b reflect.Value) bool {
+	if !that.options.NormalizeNumbers && a.Type() != b.Type() {
+		return false
+	}
+
+	if that.options.Epsilon > 0 && (isFloatValue(a) || isFloatValue(b)) {
+		af, _ := ConvertToFloat64(a.Interface())
+		bf, _ := ConvertToFloat64(b.Interface())
+		return math.Abs(af-bf) <= that.options.Epsilon
+	}
+
+	ar, aok := ratOf(a)
+	br, bok := ratOf(b)
+	if !aok || !bok {
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+	return ar.Cmp(br) == 0
+}
+
+func (that *comparator) report(path string, a, b reflect.Value) {
+	that.diffs = append(that.diffs, Difference{
+		Path:  path,
+		Left:  interfaceOf(a),
+		Right: interfaceOf(b),
+	})
+}
+
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func interfaceOf(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+func isListValue(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+func isNumberValue(v reflect.Value) bool {
+	if v.Type() == jsonNumberType {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatValue(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// ConvertToRat returns exact value of the number (json.Number, integer or finite float).
+func ConvertToRat(val interface{}) (res *big.Rat, valid bool) {
+	if val == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(val)
+	if !isNumberValue(v) {
+		return nil, false
+	}
+	return ratOf(v)
+}
+
+// ratOf returns exact value of the number.
+func ratOf(v reflect.Value) (*big.Rat, bool) {
+	if v.Type() == jsonNumberType {
+		return new(big.Rat).SetString(v.String())
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(v.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	default:
+		return nil, false
+	}
+}
+
+// mapKeyOf returns key, that does not depend on named string types.
+func mapKeyOf(k reflect.Value) interface{} {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	return k.Interface()
+}
+
+func lookupMapValue(m reflect.Value, k reflect.Value) reflect.Value {
+	if m.IsNil() {
+		return reflect.Value{}
+	}
+	tp := m.Type().Key()
+	if k.Type() != tp {
+		if !k.Type().ConvertibleTo(tp) {
+			return reflect.Value{}
+		}
+		k = k.Convert(tp)
+	}
+	return m.MapIndex(k)
+}
+
+var jsonNumberType = reflect.TypeOf(json.Number(""))
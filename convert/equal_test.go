@@ -0,0 +1,123 @@
+package convert
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDeepEqual(t *testing.T) {
+	type Test struct {
+		a       interface{}
+		b       interface{}
+		options EqualOptions
+		ok      bool
+	}
+
+	tests := map[string]Test{
+		"Equal slices in maps must be equal": {
+			a:  map[string]interface{}{"a": []interface{}{1, 2}},
+			b:  map[string]interface{}{"a": []interface{}{1, 2}},
+			ok: true,
+		},
+		"Number and int64 must differ by default": {
+			a:  json.Number("1"),
+			b:  int64(1),
+			ok: false,
+		},
+		"Number and int64 must be equal with normalization": {
+			a:       json.Number("1"),
+			b:       int64(1),
+			options: EqualOptions{NormalizeNumbers: true},
+			ok:      true,
+		},
+		"Number and float must be equal with normalization": {
+			a:       json.Number("1.5"),
+			b:       float32(1.5),
+			options: EqualOptions{NormalizeNumbers: true},
+			ok:      true,
+		},
+		"Floats must be equal with epsilon": {
+			a:       0.30000000000000004,
+			b:       0.3,
+			options: EqualOptions{Epsilon: 1e-9},
+			ok:      true,
+		},
+		"Floats must differ without epsilon": {
+			a:  0.30000000000000004,
+			b:  0.3,
+			ok: false,
+		},
+		"Nil and empty slice must differ by default": {
+			a:  []int(nil),
+			b:  []int{},
+			ok: false,
+		},
+		"Nil and empty map must be equal with option": {
+			a:       map[string]interface{}{"a": nil},
+			b:       map[string]interface{}{"a": map[string]interface{}{}},
+			options: EqualOptions{NilAsEmpty: true},
+			ok:      true,
+		},
+		"Slices of different types must be compared by content": {
+			a:  []interface{}{map[string]interface{}{"a": 1}},
+			b:  []map[string]interface{}{{"a": 1}},
+			ok: true,
+		},
+		"Structs must be compared by fields": {
+			a:  struct{ A []int }{A: []int{1}},
+			b:  struct{ A []int }{A: []int{2}},
+			ok: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.ok, DeepEqual(test.a, test.b, test.options))
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	a := map[string]interface{}{
+		"name": "a",
+		"db":   map[string]interface{}{"port": json.Number("80"), "hosts": []interface{}{"a", "b"}},
+	}
+	b := map[string]interface{}{
+		"db":   map[string]interface{}{"port": 80, "hosts": []interface{}{"a", "c", "d"}},
+		"mode": "x",
+	}
+
+	diffs := Diff(a, b, EqualOptions{NormalizeNumbers: true})
+	assert.Equal(t, []Difference{
+		{Path: "$.db.hosts[1]", Left: "b", Right: "c"},
+		{Path: "$.db.hosts[2]", Left: nil, Right: "d"},
+		{Path: "$.mode", Left: nil, Right: "x"},
+		{Path: "$.name", Left: "a", Right: nil},
+	}, diffs)
+
+	assert.True(t, IsEqualMaps(a, a))
+}
+
+func TestDeepEqual_Cyclic(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+
+	a := &Node{Name: "a"}
+	a.Next = &Node{Name: "b", Next: a}
+	b := &Node{Name: "a"}
+	b.Next = &Node{Name: "b", Next: b}
+	assert.True(t, DeepEqual(a, b, EqualOptions{}))
+
+	b.Next.Name = "c"
+	assert.False(t, DeepEqual(a, b, EqualOptions{}))
+	assert.Equal(t, []Difference{{Path: "$.Next.Name", Left: "b", Right: "c"}}, Diff(a, b, EqualOptions{}))
+
+	m1 := map[string]interface{}{"x": 1}
+	m1["self"] = m1
+	m2 := map[string]interface{}{"x": 1}
+	m2["self"] = m2
+	assert.True(t, DeepEqual(m1, m2, EqualOptions{}))
+}
@@ -0,0 +1,167 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type layeredGetter []Getter
+
+func (that layeredGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	for _, getter := range that {
+		if getter == nil {
+			continue
+		}
+		val, err := getter.GetProperty(ctx, name)
+		if err == nil {
+			return val, nil
+		}
+		if !errors.Is(err, GetErrNoMatch()) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+}
+
+// Layered is constructor for creating getter, that looks up property in the list of getters.
+// The first getter, that contains property, wins.
+// Example: Layered(env, file, defaults)
+func Layered(getters ...Getter) Getter {
+	return layeredGetter(getters)
+}
+
+type mappedGetter struct {
+	getter Getter
+	rename func(name string) string
+}
+
+func (that *mappedGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	return that.getter.GetProperty(ctx, that.rename(name))
+}
+
+// Mapped is constructor for creating getter, that renames property before lookup.
+// Example: Mapped(getter, strings.ToUpper)
+func Mapped(getter Getter, rename func(name string) string) Getter {
+	return &mappedGetter{getter: getter, rename: rename}
+}
+
+// Prefixed is constructor for creating getter, that adds prefix to the property name.
+// Example: Prefixed(getter, "db.")
+func Prefixed(getter Getter, prefix string) Getter {
+	return Mapped(getter, func(name string) string {
+		return prefix + name
+	})
+}
+
+// Defaults is constructor for creating getter, that takes missing properties from defaults.
+// Example: Defaults(getter, json.Map{"port": 80})
+func Defaults(getter Getter, defaults Getter) Getter {
+	return Layered(getter, defaults)
+}
+
+type readOnlyGetter struct {
+	getter Getter
+}
+
+func (that *readOnlyGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	return that.getter.GetProperty(ctx, name)
+}
+
+func (that *readOnlyGetter) SetProperty(ctx context.Context, name string, value interface{}) error {
+	return fmt.Errorf("key %q can not be changed %w", name, GetErrReadOnly())
+}
+
+// ReadOnly is constructor for creating getter, that rejects any modifications.
+func ReadOnly(getter Getter) GetterSetter {
+	return &readOnlyGetter{getter: getter}
+}
+
+type cachedEntry struct {
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// CachedGetter is getter, that caches found and missing properties for the specified time.
+// Other errors are not cached. Expired entries are evicted, so size of the cache
+// is limited by count of properties requested during ttl.
+// Zero value has no properties and caches nothing, use Cached for creating it.
+type CachedGetter struct {
+	mx      sync.Mutex
+	getter  Getter
+	ttl     time.Duration
+	entries map[string]*cachedEntry
+	sweepAt int // size of the cache, that triggers eviction of all expired entries
+}
+
+func (that *CachedGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	now := time.Now()
+
+	that.mx.Lock()
+	entry, ok := that.entries[name]
+	if ok && !now.Before(entry.expires) {
+		delete(that.entries, name)
+		ok = false
+	}
+	that.mx.Unlock()
+	if ok {
+		return entry.value, entry.err
+	}
+
+	if that.getter == nil {
+		return nil, fmt.Errorf("key %q not found %w", name, GetErrNoMatch())
+	}
+	val, err := that.getter.GetProperty(ctx, name)
+	if err != nil && !errors.Is(err, GetErrNoMatch()) {
+		return nil, err
+	}
+
+	that.mx.Lock()
+	if that.entries == nil {
+		that.entries = make(map[string]*cachedEntry)
+		that.sweepAt = minCacheSweep
+	}
+	if len(that.entries) >= that.sweepAt {
+		that.sweep(now)
+	}
+	that.entries[name] = &cachedEntry{value: val, err: err, expires: now.Add(that.ttl)}
+	that.mx.Unlock()
+
+	return val, err
+}
+
+// sweep evicts all expired entries. Threshold of the next sweep is doubled size of the cache,
+// so eviction takes amortized constant time per insertion.
+func (that *CachedGetter) sweep(now time.Time) {
+	for name, entry := range that.entries {
+		if !now.Before(entry.expires) {
+			delete(that.entries, name)
+		}
+	}
+	that.sweepAt = max(2*len(that.entries), minCacheSweep)
+}
+
+// minCacheSweep is the least size of the cache, that triggers eviction.
+const minCacheSweep = 64
+
+// Reset drops all cached properties.
+func (that *CachedGetter) Reset() {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+	that.entries = make(map[string]*cachedEntry)
+	that.sweepAt = minCacheSweep
+}
+
+// Cached is constructor for creating CachedGetter.
+func Cached(getter Getter, ttl time.Duration) *CachedGetter {
+	return &CachedGetter{
+		getter:  getter,
+		ttl:     ttl,
+		entries: make(map[string]*cachedEntry),
+		sweepAt: minCacheSweep,
+	}
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type getterFunc func(ctx context.Context, name string) (interface{}, error)
+
+func (that getterFunc) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	return that(ctx, name)
+}
+
+type countingGetter struct {
+	Getter
+	count int
+}
+
+func (that *countingGetter) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	that.count++
+	return that.Getter.GetProperty(ctx, name)
+}
+
+func TestLayered(t *testing.T) {
+	ctx := context.Background()
+	getter := Defaults(
+		Layered(testGetter{"host": "a"}, nil, testGetter{"host": "b", "port": 80}),
+		testGetter{"timeout": "1s"},
+	)
+
+	host, err := Type.String.Get(ctx, getter, "host", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", host)
+
+	port, err := Type.Integer.Get(ctx, getter, "port", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(80), port)
+
+	timeout, err := Type.Duration.Get(ctx, getter, "timeout", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, timeout)
+
+	_, err = getter.GetProperty(ctx, "missing")
+	assert.ErrorIs(t, err, GetErrNoMatch())
+}
+
+func TestPrefixedAndMapped(t *testing.T) {
+	ctx := context.Background()
+	base := testGetter{"db.host": "localhost", "DB_PORT": 5432}
+
+	host, err := Type.String.Get(ctx, Prefixed(base, "db."), "host", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	port, err := Type.Integer.Get(ctx, Mapped(base, strings.ToUpper), "db_port", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5432), port)
+}
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+	getter := ReadOnly(testGetter{"host": "a"})
+	val, err := getter.GetProperty(ctx, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", val)
+	assert.ErrorIs(t, getter.SetProperty(ctx, "host", "b"), GetErrReadOnly())
+}
+
+func TestCached(t *testing.T) {
+	ctx := context.Background()
+	base := &countingGetter{Getter: testGetter{"host": "a"}}
+	getter := Cached(base, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		_, _ = getter.GetProperty(ctx, "host")
+		_, _ = getter.GetProperty(ctx, "missing")
+	}
+	assert.Equal(t, 2, base.count)
+
+	getter.Reset()
+	_, _ = getter.GetProperty(ctx, "host")
+	assert.Equal(t, 3, base.count)
+}
+
+func TestCached_Eviction(t *testing.T) {
+	ctx := context.Background()
+	getter := Cached(testGetter{"host": "a"}, time.Nanosecond)
+
+	for i := 0; i < 1000; i++ {
+		_, _ = getter.GetProperty(ctx, fmt.Sprintf("key%d", i))
+	}
+	assert.LessOrEqual(t, len(getter.entries), minCacheSweep)
+
+	// Expired hit is evicted, even if it can not be refreshed
+	var down error
+	getter = Cached(getterFunc(func(ctx context.Context, name string) (interface{}, error) {
+		return "a", down
+	}), time.Millisecond)
+	_, _ = getter.GetProperty(ctx, "host")
+	assert.Len(t, getter.entries, 1)
+	time.Sleep(2 * time.Millisecond)
+	down = errors.New("down")
+	_, err := getter.GetProperty(ctx, "host")
+	assert.Error(t, err)
+	assert.Len(t, getter.entries, 0)
+}
+
+func TestCached_Zero(t *testing.T) {
+	ctx := context.Background()
+
+	var zero CachedGetter
+	_, err := zero.GetProperty(ctx, "host")
+	assert.ErrorIs(t, err, GetErrNoMatch())
+
+	getter := &CachedGetter{getter: testGetter{"host": "a"}, ttl: time.Hour}
+	val, err := getter.GetProperty(ctx, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", val)
+	assert.Len(t, getter.entries, 1)
+}
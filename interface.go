@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/adverax/types/natural"
 	"time"
 )
 
@@ -17,6 +19,17 @@ type Setter interface {
 	SetProperty(ctx context.Context, name string, value interface{}) error
 }
 
+// GetterSetter is abstract property getter and setter
+type GetterSetter interface {
+	Getter
+	Setter
+}
+
+// Remover is optional interface of GetterSetter, that can remove properties.
+type Remover interface {
+	RemoveProperty(ctx context.Context, name string) error
+}
+
 type TypeChecker interface {
 	Is(value interface{}) bool
 }
@@ -28,6 +41,12 @@ type Typer[T any] interface {
 	Cast(value interface{}, defVal T) T
 }
 
+// RequiredTyper is typer, that reads mandatory properties itself (see Require).
+type RequiredTyper[T any] interface {
+	Typer[T]
+	Require(ctx context.Context, getter Getter, name string) (res T, err error)
+}
+
 type Types struct {
 	Boolean  Typer[bool]
 	Integer  Typer[int64]
@@ -35,6 +54,8 @@ type Types struct {
 	String   Typer[string]
 	Duration Typer[time.Duration]
 	Json     Typer[json.RawMessage]
+	Fraction Typer[natural.Value]
+	Decimal  Typer[natural.Decimal]
 }
 
 var Type = &Types{
@@ -44,6 +65,8 @@ var Type = &Types{
 	String:   &StringType{},
 	Duration: &DurationType{},
 	Json:     &JsonType{},
+	Fraction: &FractionType{Epsilon: DefaultFractionEpsilon},
+	Decimal:  &DecimalType{},
 }
 
 var GetErrNoMatch = func() error {
@@ -51,3 +74,18 @@ var GetErrNoMatch = func() error {
 }
 
 var errNoMatch = errors.New("no match")
+
+var GetErrReadOnly = func() error {
+	return errReadOnly
+}
+
+var errReadOnly = errors.New("read only")
+
+// MissingPropertyError is returned when required property is absent.
+type MissingPropertyError struct {
+	Name string
+}
+
+func (that *MissingPropertyError) Error() string {
+	return fmt.Sprintf("property %q is required", that.Name)
+}
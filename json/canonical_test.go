@@ -0,0 +1,65 @@
+package json
+
+import (
+	"crypto/md5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	type Test struct {
+		src string
+		dst string
+	}
+
+	tests := map[string]Test{
+		"Keys must be sorted": {
+			src: `{"b": 1, "a": {"d": true, "c": null}}`,
+			dst: `{"a":{"c":null,"d":true},"b":1}`,
+		},
+		"Keys must be sorted by UTF-16 code units": {
+			src: `{"😀": 1, "דּ": 2, "€": 3, "1": 4}`,
+			dst: "{\"1\":4,\"€\":3,\"\U0001F600\":1,\"דּ\":2}",
+		},
+		"Numbers must be formatted as in ECMAScript": {
+			src: `[1.0, 4.50, 2e-3, 0.000001, 1e-7, 1e21, 1e20, -0, 333333333.33333329, 1E30]`,
+			dst: `[1,4.5,0.002,0.000001,1e-7,1e+21,100000000000000000000,0,333333333.3333333,1e+30]`,
+		},
+		"Strings must be minimally escaped": {
+			src: `"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/"`,
+			dst: `"€$\u000f\nA'B\"\\\\\"/"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := Canonicalize(RawMessage(test.src))
+			require.NoError(t, err)
+			assert.Equal(t, test.dst, string(res))
+		})
+	}
+
+	for _, src := range []string{`{} {}`, `{"a":1}}`, `[1]]`, `{"a":1} ,`} {
+		_, err := Canonicalize(RawMessage(src))
+		assert.Error(t, err, src)
+	}
+}
+
+func TestMapHash(t *testing.T) {
+	a, err := NewMap([]byte(`{"a": 1.0, "b": [1, 2]}`))
+	require.NoError(t, err)
+	b, err := NewMap([]byte(`{"b": [1, 2.00], "a": 1}`))
+	require.NoError(t, err)
+
+	ha, err := a.Hash()
+	require.NoError(t, err)
+	hb, err := b.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, ha, hb)
+	assert.Len(t, ha, 64)
+
+	hm, err := a.HashWith(md5.New())
+	require.NoError(t, err)
+	assert.Len(t, hm, 32)
+}
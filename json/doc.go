@@ -0,0 +1,491 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"github.com/adverax/types"
+	"github.com/adverax/types/convert"
+	"math/bits"
+	"reflect"
+	"strings"
+)
+
+// Doc is immutable JSON object with structural sharing (hash array mapped trie).
+// All modifications return new document, that shares unchanged branches with the original,
+// so snapshots are cheap. Nested objects are represented as Doc too.
+// Values are copied, when they enter and leave the document, so snapshots can not be changed by callers.
+// Zero value is empty document.
+type Doc struct {
+	root *hamtNode
+	size int
+}
+
+// Len returns count of keys of the top level.
+func (that Doc) Len() int {
+	return that.size
+}
+
+// Get returns value by path (see Map.GetProperty for path syntax).
+// Lists are returned as copies.
+func (that Doc) Get(path string) (interface{}, bool) {
+	var val interface{} = that
+	for _, key := range splitPath(path) {
+		doc, ok := val.(Doc)
+		if !ok {
+			return nil, false
+		}
+		val, ok = doc.get(key)
+		if !ok {
+			return nil, false
+		}
+	}
+	return exportDocValue(val), true
+}
+
+func (that Doc) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	if val, ok := that.Get(name); ok {
+		return val, nil
+	}
+	return nil, fmt.Errorf("key %q not found %w", name, types.GetErrNoMatch())
+}
+
+// With returns new document with value by path. Missing intermediate objects are created,
+// intermediate values, that are not objects, are replaced by objects.
+func (that Doc) With(path string, value interface{}) Doc {
+	return that.with(splitPath(path), newDocValue(value))
+}
+
+func (that Doc) with(path []string, value interface{}) Doc {
+	if len(path) == 1 {
+		return that.set(path[0], value)
+	}
+	var child Doc
+	if val, ok := that.get(path[0]); ok {
+		child, _ = val.(Doc)
+	}
+	return that.set(path[0], child.with(path[1:], value))
+}
+
+// Without returns new document without value by path.
+func (that Doc) Without(path string) Doc {
+	return that.without(splitPath(path))
+}
+
+func (that Doc) without(path []string) Doc {
+	if len(path) == 1 {
+		return that.remove(path[0])
+	}
+	val, ok := that.get(path[0])
+	if !ok {
+		return that
+	}
+	child, ok := val.(Doc)
+	if !ok {
+		return that
+	}
+	res := child.without(path[1:])
+	if res.root == child.root {
+		return that
+	}
+	return that.set(path[0], res)
+}
+
+// Range calls fn for each key of the top level in unspecified order, until fn returns false.
+// Lists are passed as copies.
+func (that Doc) Range(fn func(key string, value interface{}) bool) {
+	that.iterate(func(key string, value interface{}) bool {
+		return fn(key, exportDocValue(value))
+	})
+}
+
+// iterate is like Range, but passes internal values.
+func (that Doc) iterate(fn func(key string, value interface{}) bool) {
+	if that.root != nil {
+		that.root.iterate(fn)
+	}
+}
+
+// Keys returns keys of the top level in unspecified order.
+func (that Doc) Keys() []string {
+	keys := make([]string, 0, that.size)
+	that.iterate(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Equal checks if documents have the same content.
+func (that Doc) Equal(other Doc) bool {
+	if that.root == other.root {
+		return true
+	}
+	if that.size != other.size {
+		return false
+	}
+	res := true
+	that.iterate(func(key string, value interface{}) bool {
+		v, ok := other.get(key)
+		res = ok && isEqualDocValues(value, v)
+		return res
+	})
+	return res
+}
+
+// ToMap converts document into mutable Map.
+func (that Doc) ToMap() Map {
+	res := make(Map, that.size)
+	that.iterate(func(key string, value interface{}) bool {
+		res[key] = toMapValue(value)
+		return true
+	})
+	return res
+}
+
+// DeepCopy returns document itself, because it is immutable (see convert.DeepCopier).
+func (that Doc) DeepCopy() interface{} {
+	return that
+}
+
+func (that Doc) MarshalJSON() ([]byte, error) {
+	return Marshal(that.ToMap())
+}
+
+func (that *Doc) UnmarshalJSON(data []byte) error {
+	m, err := NewMap(data)
+	if err != nil {
+		return err
+	}
+	*that = NewDoc(m)
+	return nil
+}
+
+func (that Doc) String() string {
+	return that.ToMap().String()
+}
+
+func (that Doc) get(key string) (interface{}, bool) {
+	if that.root == nil {
+		return nil, false
+	}
+	return that.root.get(hashKey(key), 0, key)
+}
+
+func (that Doc) set(key string, value interface{}) Doc {
+	entry := &hamtEntry{hash: hashKey(key), key: key, value: value}
+	if that.root == nil {
+		return Doc{root: newHamtNode(entry, 0), size: 1}
+	}
+	root, added := that.root.set(entry, 0)
+	if added {
+		return Doc{root: root, size: that.size + 1}
+	}
+	return Doc{root: root, size: that.size}
+}
+
+func (that Doc) remove(key string) Doc {
+	if that.root == nil {
+		return that
+	}
+	root, removed := that.root.remove(hashKey(key), 0, key)
+	if !removed {
+		return that
+	}
+	return Doc{root: root, size: that.size - 1}
+}
+
+// NewDoc is constructor for creating Doc from Map.
+func NewDoc(m Map) Doc {
+	var res Doc
+	for k, v := range m {
+		res = res.set(k, newDocValue(v))
+	}
+	return res
+}
+
+func newDocValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Map:
+		return NewDoc(v)
+	case map[string]interface{}:
+		return NewDoc(v)
+	case []Map:
+		list := make([]Doc, len(v))
+		for i, m := range v {
+			list[i] = NewDoc(m)
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, vv := range v {
+			list[i] = newDocValue(vv)
+		}
+		return list
+	default:
+		return exportDocValue(v)
+	}
+}
+
+// exportDocValue returns copy of the value, that can be modified without affecting of documents.
+func exportDocValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, Doc, string, bool, Number, float64, int64, int:
+		return v
+	case []Doc:
+		list := make([]Doc, len(v))
+		copy(list, v)
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, vv := range v {
+			list[i] = exportDocValue(vv)
+		}
+		return list
+	default:
+		return convert.DeepCopy(v)
+	}
+}
+
+func toMapValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Doc:
+		return v.ToMap()
+	case []Doc:
+		list := make([]Map, len(v))
+		for i, d := range v {
+			list[i] = d.ToMap()
+		}
+		return list
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, vv := range v {
+			list[i] = toMapValue(vv)
+		}
+		return list
+	default:
+		return exportDocValue(v)
+	}
+}
+
+func isEqualDocValues(a, b interface{}) bool {
+	switch av := a.(type) {
+	case Doc:
+		bv, ok := b.(Doc)
+		return ok && av.Equal(bv)
+	case []Doc:
+		bv, ok := b.([]Doc)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !av[i].Equal(bv[i]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !isEqualDocValues(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// splitPath splits path in the same manner as Map.GetProperty.
+func splitPath(path string) []string {
+	if strings.HasPrefix(path, "$.") {
+		keys := strings.Split(path[2:], ".")
+		if len(keys) != 1 {
+			return keys
+		}
+	}
+	return []string{path}
+}
+
+const (
+	hamtBits     = 5
+	hamtMask     = 1<<hamtBits - 1
+	hamtMaxShift = 30
+)
+
+type hamtEntry struct {
+	hash  uint32
+	key   string
+	value interface{}
+	node  *hamtNode
+}
+
+// hamtNode is node of the trie. Collision nodes are placed below the last level
+// and contain entries with equal hashes in the plain list.
+type hamtNode struct {
+	bitmap    uint32
+	collision bool
+	entries   []*hamtEntry
+}
+
+func (that *hamtNode) get(hash uint32, shift uint, key string) (interface{}, bool) {
+	for {
+		if that.collision {
+			for _, e := range that.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			return nil, false
+		}
+		bit := bitOf(hash, shift)
+		if that.bitmap&bit == 0 {
+			return nil, false
+		}
+		e := that.entries[that.index(bit)]
+		if e.node == nil {
+			if e.key == key {
+				return e.value, true
+			}
+			return nil, false
+		}
+		that = e.node
+		shift += hamtBits
+	}
+}
+
+func (that *hamtNode) set(entry *hamtEntry, shift uint) (*hamtNode, bool) {
+	if that.collision {
+		for i, e := range that.entries {
+			if e.key == entry.key {
+				return that.replace(i, entry), false
+			}
+		}
+		res := &hamtNode{collision: true, entries: make([]*hamtEntry, len(that.entries), len(that.entries)+1)}
+		copy(res.entries, that.entries)
+		res.entries = append(res.entries, entry)
+		return res, true
+	}
+
+	bit := bitOf(entry.hash, shift)
+	idx := that.index(bit)
+	if that.bitmap&bit == 0 {
+		res := &hamtNode{bitmap: that.bitmap | bit, entries: make([]*hamtEntry, len(that.entries)+1)}
+		copy(res.entries, that.entries[:idx])
+		res.entries[idx] = entry
+		copy(res.entries[idx+1:], that.entries[idx:])
+		return res, true
+	}
+
+	e := that.entries[idx]
+	if e.node != nil {
+		child, added := e.node.set(entry, shift+hamtBits)
+		return that.replace(idx, &hamtEntry{node: child}), added
+	}
+	if e.key == entry.key {
+		return that.replace(idx, entry), false
+	}
+	child := newHamtNode(e, shift+hamtBits)
+	child, _ = child.set(entry, shift+hamtBits)
+	return that.replace(idx, &hamtEntry{node: child}), true
+}
+
+func (that *hamtNode) remove(hash uint32, shift uint, key string) (*hamtNode, bool) {
+	if that.collision {
+		for i, e := range that.entries {
+			if e.key == key {
+				return that.delete(i, 0), true
+			}
+		}
+		return that, false
+	}
+
+	bit := bitOf(hash, shift)
+	if that.bitmap&bit == 0 {
+		return that, false
+	}
+	idx := that.index(bit)
+	e := that.entries[idx]
+	if e.node == nil {
+		if e.key != key {
+			return that, false
+		}
+		return that.delete(idx, bit), true
+	}
+
+	child, removed := e.node.remove(hash, shift+hamtBits, key)
+	if !removed {
+		return that, false
+	}
+	if child == nil {
+		return that.delete(idx, bit), true
+	}
+	if len(child.entries) == 1 && child.entries[0].node == nil {
+		// Pull single entry up to keep the trie compact.
+		return that.replace(idx, child.entries[0]), true
+	}
+	return that.replace(idx, &hamtEntry{node: child}), true
+}
+
+func (that *hamtNode) iterate(fn func(key string, value interface{}) bool) bool {
+	for _, e := range that.entries {
+		if e.node != nil {
+			if !e.node.iterate(fn) {
+				return false
+			}
+			continue
+		}
+		if !fn(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (that *hamtNode) index(bit uint32) int {
+	return bits.OnesCount32(that.bitmap & (bit - 1))
+}
+
+func (that *hamtNode) replace(idx int, entry *hamtEntry) *hamtNode {
+	res := &hamtNode{bitmap: that.bitmap, collision: that.collision, entries: make([]*hamtEntry, len(that.entries))}
+	copy(res.entries, that.entries)
+	res.entries[idx] = entry
+	return res
+}
+
+func (that *hamtNode) delete(idx int, bit uint32) *hamtNode {
+	if len(that.entries) == 1 {
+		return nil
+	}
+	res := &hamtNode{bitmap: that.bitmap &^ bit, collision: that.collision, entries: make([]*hamtEntry, 0, len(that.entries)-1)}
+	res.entries = append(res.entries, that.entries[:idx]...)
+	res.entries = append(res.entries, that.entries[idx+1:]...)
+	return res
+}
+
+func newHamtNode(entry *hamtEntry, shift uint) *hamtNode {
+	if shift > hamtMaxShift {
+		return &hamtNode{collision: true, entries: []*hamtEntry{entry}}
+	}
+	return &hamtNode{bitmap: bitOf(entry.hash, shift), entries: []*hamtEntry{entry}}
+}
+
+func bitOf(hash uint32, shift uint) uint32 {
+	return 1 << ((hash >> shift) & hamtMask)
+}
+
+// hashKey is FNV-1a hash of the key.
+func hashKey(key string) uint32 {
+	hash := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= 16777619
+	}
+	return hash
+}
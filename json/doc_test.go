@@ -0,0 +1,119 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"github.com/adverax/types"
+	"github.com/adverax/types/convert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDoc(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewMap([]byte(`{"db": {"host": "a", "port": 80}, "tags": ["x", "y"], "items": [{"id": 1}]}`))
+	require.NoError(t, err)
+
+	v1 := NewDoc(m)
+	v2 := v1.With("$.db.host", "b")
+	v3 := v2.Without("$.db.port").With("$.cache.size", Number("10"))
+
+	assert.Equal(t, "a", types.Type.String.Cast(first(v1.Get("$.db.host")), ""))
+	assert.Equal(t, "b", types.Type.String.Cast(first(v2.Get("$.db.host")), ""))
+	_, ok := v3.Get("$.db.port")
+	assert.False(t, ok)
+
+	port, err := types.Type.Integer.Get(ctx, v2, "$.db.port", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(80), port)
+
+	assert.True(t, v1.Equal(NewDoc(m)))
+	assert.False(t, v1.Equal(v2))
+	assert.True(t, v1.Equal(v2.With("$.db.host", "a")))
+	assert.Equal(t, m, v1.ToMap())
+
+	data, err := v3.MarshalJSON()
+	require.NoError(t, err)
+	var d Doc
+	require.NoError(t, d.UnmarshalJSON(data))
+	assert.True(t, v3.Equal(d))
+}
+
+func TestDocLarge(t *testing.T) {
+	var doc Doc
+	for i := 0; i < 10000; i++ {
+		doc = doc.With(fmt.Sprintf("key%d", i), i)
+	}
+	assert.Equal(t, 10000, doc.Len())
+	snapshot := doc
+
+	for i := 0; i < 10000; i += 2 {
+		doc = doc.Without(fmt.Sprintf("key%d", i))
+	}
+	assert.Equal(t, 5000, doc.Len())
+	assert.Equal(t, 10000, snapshot.Len())
+
+	for i := 0; i < 10000; i++ {
+		val, ok := doc.Get(fmt.Sprintf("key%d", i))
+		assert.Equal(t, i%2 == 1, ok)
+		if ok {
+			assert.Equal(t, i, val)
+		}
+		val, ok = snapshot.Get(fmt.Sprintf("key%d", i))
+		assert.True(t, ok)
+		assert.Equal(t, i, val)
+	}
+	assert.Len(t, doc.Keys(), 5000)
+}
+
+func first(val interface{}, _ bool) interface{} {
+	return val
+}
+
+func TestDoc_DeepCopy(t *testing.T) {
+	type Config struct {
+		Doc  *Doc
+		Docs Map
+	}
+
+	doc := NewDoc(Map{"a": Number("1")})
+	src := Config{Doc: &doc, Docs: Map{"doc": &doc}}
+	dst := convert.DeepCopy(src).(Config)
+
+	require.NotNil(t, dst.Doc)
+	assert.NotSame(t, src.Doc, dst.Doc)
+	assert.True(t, doc.Equal(*dst.Doc))
+	assert.Same(t, dst.Doc, dst.Docs["doc"])
+
+	var empty *Doc
+	assert.Nil(t, convert.DeepCopy(empty))
+}
+
+func TestDoc_Immutable(t *testing.T) {
+	tags := []interface{}{"x", []interface{}{"y"}}
+	codes := []string{"a"}
+	m := Map{"tags": tags, "codes": codes}
+	doc := NewDoc(m)
+
+	// Source slices are copied
+	tags[0] = "changed"
+	codes[0] = "changed"
+
+	// Returned slices are copies
+	val, ok := doc.Get("tags")
+	require.True(t, ok)
+	list := val.([]interface{})
+	list[0] = "changed"
+	list[1].([]interface{})[0] = "changed"
+	val, _ = doc.Get("codes")
+	val.([]string)[0] = "changed"
+	doc.Range(func(key string, value interface{}) bool {
+		if list, ok := value.([]interface{}); ok {
+			list[0] = "changed"
+		}
+		return true
+	})
+
+	assert.Equal(t, Map{"tags": []interface{}{"x", []interface{}{"y"}}, "codes": []string{"a"}}, doc.ToMap())
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/adverax/types/convert"
 	jsoniter "github.com/json-iterator/go"
 	"io"
 )
@@ -55,37 +56,84 @@ func Ensure(doc []byte) []byte {
 }
 
 // TypeOf is a helper function to determine the type of a JSON document.
-// It takes a document and returns the type (array, object).
+// It takes a document and returns its kind. The whole document is validated.
+// Document is treated as ndjson, if it consists of several objects, one object per line.
 // Example: TypeOf(doc)
-func TypeOf(in io.Reader) (string, error) {
-	dec := json.NewDecoder(in)
-	// Get just the first valid JSON token from input
-	t, err := dec.Token()
+func TypeOf(in io.Reader) (Kind, error) {
+	data, err := io.ReadAll(in)
 	if err != nil {
-		return "", err
-	}
-	if d, ok := t.(json.Delim); ok {
-		// The first token is a delimiter, so this is an array or an object
-		switch d {
-		case '[':
-			return "array", nil
-		case '{':
-			return "object", nil
-		default: // ] or }
-			return "", errors.New("Unexpected delimiter")
+		return KindInvalid, err
+	}
+	return KindOf(data)
+}
+
+// KindOf is a helper function to determine the type of a JSON document (see TypeOf).
+func KindOf(doc []byte) (Kind, error) {
+	err := Validate(doc)
+	if err == nil {
+		return kindOfValue(doc), nil
+	}
+
+	if isNDJSON(doc) {
+		return KindNDJSON, nil
+	}
+
+	return KindInvalid, err
+}
+
+// isNDJSON checks if document is sequence of the several objects, one object per line.
+// Blank lines are ignored like in NewMapsFromNDJSON.
+func isNDJSON(doc []byte) bool {
+	count := 0
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if !json.Valid(line) || kindOfValue(line) != KindObject {
+			return false
 		}
+		count++
 	}
-	return "", errors.New("Input does not represent a JSON object or array")
+	return count > 1
+}
+
+// Validate is a helper function to check syntax of the whole JSON document.
+// It returns *SyntaxError with position of the error.
+func Validate(doc []byte) error {
+	var raw json.RawMessage
+	err := json.Unmarshal(doc, &raw)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return newSyntaxError(doc, syntaxErr.Offset, syntaxErr.Error())
+	}
+	return err
 }
 
 // AsArray is a helper function to ensure a JSON document is an array.
+// Records of NDJSON document become elements of the array.
 func AsArray(data []byte) ([]byte, error) {
-	isArray, err := IsArray(data)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return RawMessage("[]"), nil
+	}
+	kind, err := KindOf(data)
 	if err != nil {
-		return nil, fmt.Errorf("JsonIsArray: %w", err)
+		return nil, fmt.Errorf("JsonType: %w", err)
 	}
-	if isArray {
+	switch kind {
+	case KindArray:
 		return data, nil
+	case KindNDJSON:
+		var records [][]byte
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) != 0 {
+				records = append(records, line)
+			}
+		}
+		data = bytes.Join(records, []byte(","))
 	}
 
 	return []byte(fmt.Sprintf("[%s]", string(data))), nil
@@ -100,7 +148,7 @@ func IsArray(data []byte) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("JsonType: %w", err)
 	}
-	return tp == "array", nil
+	return tp == KindArray, nil
 }
 
 // IsObject is a helper function to determine if a JSON document is an object.
@@ -112,7 +160,7 @@ func IsObject(data []byte) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("JsonType: %w", err)
 	}
-	return tp == "object", nil
+	return tp == KindObject, nil
 }
 
 // Merge is a helper function to merge JSON documents.
@@ -135,8 +183,20 @@ func Merge(docs ...[]byte) ([]byte, error) {
 // Empty is empty document
 var Empty = RawMessage("{}")
 
+// IsEmpty checks if document is blank, null, empty object or empty array.
 func IsEmpty(doc []byte) bool {
-	return len(doc) <= 2
+	doc = bytes.TrimSpace(doc)
+	if len(doc) == 0 || string(doc) == "null" {
+		return true
+	}
+	if len(doc) < 2 {
+		return false
+	}
+	first, last := doc[0], doc[len(doc)-1]
+	if (first == '{' && last == '}') || (first == '[' && last == ']') {
+		return len(bytes.TrimSpace(doc[1:len(doc)-1])) == 0
+	}
+	return false
 }
 
 // CoalesceString is a helper function to coalesce a string from multiple documents.
@@ -182,18 +242,20 @@ func Normalize(data RawMessage) (RawMessage, error) {
 	return MarshalIndent(m)
 }
 
+// IsEqual checks if documents are semantically equal.
+// Numbers are compared by value, so 1 and 1.0 are equal.
 func IsEqual(a, b RawMessage) bool {
-	aa, err := Normalize(a)
+	aa, err := NewMap(a)
 	if err != nil {
 		return false
 	}
 
-	bb, err := Normalize(b)
+	bb, err := NewMap(b)
 	if err != nil {
 		return false
 	}
 
-	return string(aa) == string(bb)
+	return convert.DeepEqual(aa, bb, convert.EqualOptions{NormalizeNumbers: true})
 }
 
 var dummyAction = func(Map) error { return nil }
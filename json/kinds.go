@@ -0,0 +1,102 @@
+package json
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Kind is type of the JSON document.
+type Kind int
+
+const (
+	KindInvalid Kind = iota
+	KindObject
+	KindArray
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+	// KindNDJSON is sequence of the newline delimited objects.
+	KindNDJSON
+)
+
+func (that Kind) String() string {
+	switch that {
+	case KindObject:
+		return "object"
+	case KindArray:
+		return "array"
+	case KindString:
+		return "string"
+	case KindNumber:
+		return "number"
+	case KindBool:
+		return "bool"
+	case KindNull:
+		return "null"
+	case KindNDJSON:
+		return "ndjson"
+	default:
+		return "invalid"
+	}
+}
+
+// IsScalar checks if kind is not object, array or ndjson.
+func (that Kind) IsScalar() bool {
+	switch that {
+	case KindString, KindNumber, KindBool, KindNull:
+		return true
+	default:
+		return false
+	}
+}
+
+// SyntaxError is error of the JSON syntax with position.
+// Line and Column are 1-based, Column is counted in bytes.
+type SyntaxError struct {
+	Msg    string
+	Offset int64
+	Line   int
+	Column int
+}
+
+func (that *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at line %d, column %d (offset %d)", that.Msg, that.Line, that.Column, that.Offset)
+}
+
+func newSyntaxError(doc []byte, offset int64, msg string) *SyntaxError {
+	if offset > int64(len(doc)) {
+		offset = int64(len(doc))
+	}
+	prefix := doc[:offset]
+	line := bytes.Count(prefix, []byte{'\n'}) + 1
+	column := len(prefix) - bytes.LastIndexByte(prefix, '\n')
+	return &SyntaxError{
+		Msg:    msg,
+		Offset: offset,
+		Line:   line,
+		Column: column,
+	}
+}
+
+// kindOfValue returns kind of the valid JSON value by its first character.
+func kindOfValue(doc []byte) Kind {
+	doc = bytes.TrimSpace(doc)
+	if len(doc) == 0 {
+		return KindInvalid
+	}
+	switch doc[0] {
+	case '{':
+		return KindObject
+	case '[':
+		return KindArray
+	case '"':
+		return KindString
+	case 't', 'f':
+		return KindBool
+	case 'n':
+		return KindNull
+	default:
+		return KindNumber
+	}
+}
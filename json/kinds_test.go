@@ -0,0 +1,78 @@
+package json
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	type Test struct {
+		src  string
+		kind Kind
+		ok   bool
+	}
+
+	tests := map[string]Test{
+		"Object":          {src: ` {"a": 1} `, kind: KindObject, ok: true},
+		"Array":           {src: `[1, 2]`, kind: KindArray, ok: true},
+		"String":          {src: `"abc"`, kind: KindString, ok: true},
+		"Number":          {src: `-1.5e3`, kind: KindNumber, ok: true},
+		"Bool":            {src: `false`, kind: KindBool, ok: true},
+		"Null":            {src: ` null`, kind: KindNull, ok: true},
+		"NDJSON":          {src: "{\"a\": 1}\n{\"a\": 2}", kind: KindNDJSON, ok: true},
+		"Objects in line": {src: `{"a": 1} {"a": 2}`, ok: false},
+		"Truncated array": {src: `[1,`, ok: false},
+		"Trailing data":   {src: `[1] 2`, ok: false},
+		"Empty":           {src: ``, ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, err := TypeOf(strings.NewReader(test.src))
+			if test.ok {
+				require.NoError(t, err)
+				assert.Equal(t, test.kind, kind)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	err := Validate([]byte("{\n  \"a\": 1,\n  \"b\": x\n}"))
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, 3, syntaxErr.Line)
+	assert.Equal(t, 9, syntaxErr.Column)
+	assert.Equal(t, int64(20), syntaxErr.Offset)
+
+	err = Validate([]byte(`[1,`))
+	require.ErrorAs(t, err, &syntaxErr)
+	assert.Equal(t, int64(3), syntaxErr.Offset)
+}
+
+func TestAsArrayAndEnsure(t *testing.T) {
+	res, err := AsArray([]byte(`5`))
+	require.NoError(t, err)
+	assert.Equal(t, `[5]`, string(res))
+
+	res, err = AsArray([]byte(` [1] `))
+	require.NoError(t, err)
+	assert.Equal(t, ` [1] `, string(res))
+
+	_, err = AsArray([]byte(`[1,`))
+	assert.Error(t, err)
+
+	res, err = AsArray([]byte("{\"a\": 1}\n\n{\"a\": 2}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"a": 1},{"a": 2}]`, string(res))
+	assert.True(t, json.Valid(res))
+
+	assert.Equal(t, "{}", string(Ensure([]byte(" {} "))))
+	assert.Equal(t, "{}", string(Ensure([]byte("null"))))
+	assert.Equal(t, "12", string(Ensure([]byte("12"))))
+}
@@ -2,11 +2,14 @@ package json
 
 import (
 	"context"
-	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"github.com/adverax/types"
 	"github.com/adverax/types/convert"
+	"github.com/adverax/types/natural"
+	"hash"
 	"os"
 	"reflect"
 	"strings"
@@ -21,13 +24,32 @@ func (that Map) Contains(name string) bool {
 	return err == nil
 }
 
+// Hash returns SHA-256 digest of the canonical form of the map (see HashWith).
 func (that Map) Hash() (string, error) {
-	data, err := MarshalIndent(that)
+	return that.HashWith(sha256.New())
+}
+
+// HashWith returns hex encoded digest of the canonical form of the map (see Canonicalize),
+// so equal documents have equal hashes regardless of the key order and number formatting.
+func (that Map) HashWith(h hash.Hash) (string, error) {
+	data, err := that.Canonical()
+	if err != nil {
+		return "", fmt.Errorf("Canonical: %w", err)
+	}
+
+	h.Reset()
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Canonical returns canonical JSON representation of the map (see Canonicalize).
+func (that Map) Canonical() (RawMessage, error) {
+	data, err := Marshal(that)
 	if err != nil {
-		return "", fmt.Errorf("MarshalSortedIndent: %w", err)
+		return nil, fmt.Errorf("Marshal: %w", err)
 	}
 
-	return digestString(string(data)), nil
+	return Canonicalize(data)
 }
 
 func (that Map) SaveToFile(filename string) error {
@@ -144,6 +166,35 @@ func (that Map) SetProperty(
 	return nil
 }
 
+// RemoveProperty removes property with the same path semantics as SetProperty.
+// Missing property is ignored.
+func (that Map) RemoveProperty(
+	ctx context.Context,
+	name string,
+) error {
+	if !strings.HasPrefix(name, "$.") {
+		delete(that, name)
+		return nil
+	}
+
+	path := strings.Split(name[2:], ".")
+	if len(path) == 1 {
+		delete(that, name)
+		return nil
+	}
+
+	m := that
+	for _, key := range path[:len(path)-1] {
+		vv, ok := m[key].(Map)
+		if !ok {
+			return nil
+		}
+		m = vv
+	}
+	delete(m, path[len(path)-1])
+	return nil
+}
+
 // ExpandBy is routine, that allow expand map by additional values from another map (recursive).
 func (that Map) ExpandBy(right Map) {
 	for key, rightVal := range right {
@@ -160,7 +211,7 @@ func (that Map) ExpandBy(right Map) {
 				that[key] = mm
 				mm.ExpandBy(right)
 			} else {
-				that[key] = convert.CloneValue(rightVal)
+				that[key] = convert.DeepCopy(rightVal)
 			}
 		}
 	}
@@ -344,6 +395,80 @@ func (that Map) GetJson(
 	return types.Type.Json.Get(ctx, that, name, defVal)
 }
 
+// GetNatural returns natural fraction (see types.FractionType).
+func (that Map) GetNatural(
+	ctx context.Context,
+	name string,
+	defVal natural.Value,
+) (res natural.Value, err error) {
+	return types.Type.Fraction.Get(ctx, that, name, defVal)
+}
+
+// GetDecimal returns exact decimal (see types.DecimalType).
+func (that Map) GetDecimal(
+	ctx context.Context,
+	name string,
+	defVal natural.Decimal,
+) (res natural.Decimal, err error) {
+	return types.Type.Decimal.Get(ctx, that, name, defVal)
+}
+
+func (that Map) GetBooleanRequired(
+	ctx context.Context,
+	name string,
+) (res bool, err error) {
+	return types.Require(ctx, types.Type.Boolean, that, name)
+}
+
+func (that Map) GetStringRequired(
+	ctx context.Context,
+	name string,
+) (res string, err error) {
+	return types.Require(ctx, types.Type.String, that, name)
+}
+
+func (that Map) GetIntegerRequired(
+	ctx context.Context,
+	name string,
+) (res int64, err error) {
+	return types.Require(ctx, types.Type.Integer, that, name)
+}
+
+func (that Map) GetFloatRequired(
+	ctx context.Context,
+	name string,
+) (res float64, err error) {
+	return types.Require(ctx, types.Type.Float, that, name)
+}
+
+func (that Map) GetDurationRequired(
+	ctx context.Context,
+	name string,
+) (res time.Duration, err error) {
+	return types.Require(ctx, types.Type.Duration, that, name)
+}
+
+func (that Map) GetJsonRequired(
+	ctx context.Context,
+	name string,
+) (res RawMessage, err error) {
+	return types.Require(ctx, types.Type.Json, that, name)
+}
+
+func (that Map) GetNaturalRequired(
+	ctx context.Context,
+	name string,
+) (res natural.Value, err error) {
+	return types.Require(ctx, types.Type.Fraction, that, name)
+}
+
+func (that Map) GetDecimalRequired(
+	ctx context.Context,
+	name string,
+) (res natural.Decimal, err error) {
+	return types.Require(ctx, types.Type.Decimal, that, name)
+}
+
 func (that Map) SetBoolean(
 	ctx context.Context,
 	name string,
@@ -413,18 +538,12 @@ func (that Map) NewScope(name string) Map {
 	return mmm
 }
 
+// Clone returns deep copy of the map (see convert.DeepCopy).
 func (that Map) Clone() Map {
-	cp := make(Map)
-	for k, v := range that {
-		vm, ok := v.(Map)
-		if ok {
-			cp[k] = vm.Clone()
-		} else {
-			cp[k] = v
-		}
+	if that == nil {
+		return make(Map)
 	}
-
-	return cp
+	return convert.DeepCopy(that).(Map)
 }
 
 // NewMap is constructor for creating Map from JSON source.
@@ -580,10 +699,6 @@ func isAllMaps(list []interface{}) bool {
 	return true
 }
 
-func digestString(s string) string {
-	return fmt.Sprintf("%x", md5.Sum([]byte(s)))
-}
-
 // Coalesce is a helper function to coalesce a string from multiple documents.
 func Coalesce(getter func(Map) (bool, error), docs ...Map) (bool, error) {
 	for _, doc := range docs {
@@ -0,0 +1,145 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"github.com/adverax/types"
+	"github.com/adverax/types/natural"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMapClone(t *testing.T) {
+	src, err := NewMap([]byte(`{"items": [{"id": 1}], "tags": ["a", 1], "db": {"hosts": ["a"]}}`))
+	require.NoError(t, err)
+
+	dst := src.Clone()
+	assert.Equal(t, src, dst)
+
+	dst["items"].([]Map)[0]["id"] = 2
+	dst["tags"].([]interface{})[0] = "b"
+	dst.Scope("db")["hosts"].([]interface{})[0] = "b"
+	assert.Equal(t, Number("1"), src["items"].([]Map)[0]["id"])
+	assert.Equal(t, "a", src["tags"].([]interface{})[0])
+	assert.Equal(t, "a", src.Scope("db")["hosts"].([]interface{})[0])
+
+	dst = Map{}
+	dst.ExpandBy(src)
+	dst["tags"].([]interface{})[0] = "b"
+	assert.Equal(t, "a", src["tags"].([]interface{})[0])
+}
+
+func TestMapGetNatural(t *testing.T) {
+	m, err := NewMap([]byte(`{"a": "3/4", "b": 0.5, "c": {"num": 1, "div": 3}, "d": "bad", "e": {"f": "-1 1/2"}}`))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	def := natural.Value{Num: 1, Div: 1}
+
+	v, err := m.GetNatural(ctx, "a", def)
+	require.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: 3, Div: 4}, v)
+
+	v, err = m.GetNatural(ctx, "b", def)
+	require.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: 1, Div: 2}, v)
+
+	v, err = m.GetNatural(ctx, "c", def)
+	require.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: 1, Div: 3}, v)
+
+	v, err = m.GetNatural(ctx, "$.e.f", def)
+	require.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: -3, Div: 2}, v)
+
+	v, err = m.GetNatural(ctx, "x", def)
+	require.NoError(t, err)
+	assert.Equal(t, def, v)
+
+	_, err = m.GetNatural(ctx, "d", def)
+	assert.Error(t, err)
+
+	_, err = m.GetNaturalRequired(ctx, "x")
+	var missing *types.MissingPropertyError
+	assert.ErrorAs(t, err, &missing)
+}
+
+func TestMapGetDecimal(t *testing.T) {
+	m, err := NewMap([]byte(`{"total": 12345678901234567890.10, "tax": "0.20"}`))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	v, err := m.GetDecimal(ctx, "total", natural.Decimal{})
+	require.NoError(t, err)
+	assert.Equal(t, "12345678901234567890.10", v.String())
+
+	v, err = m.GetDecimalRequired(ctx, "tax")
+	require.NoError(t, err)
+	assert.Equal(t, "0.20", v.String())
+
+	_, err = m.GetDecimalRequired(ctx, "discount")
+	var missing *types.MissingPropertyError
+	assert.ErrorAs(t, err, &missing)
+}
+
+func TestMapGetRequired(t *testing.T) {
+	m, err := NewMap([]byte(`{"flag": true, "name": "app", "port": 8080, "ratio": 0.5, "timeout": "1s", "raw": {"a": 1}, "bad": "x"}`))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	flag, err := m.GetBooleanRequired(ctx, "flag")
+	require.NoError(t, err)
+	assert.True(t, flag)
+
+	name, err := m.GetStringRequired(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "app", name)
+
+	port, err := m.GetIntegerRequired(ctx, "port")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8080), port)
+
+	ratio, err := m.GetFloatRequired(ctx, "ratio")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, ratio)
+
+	timeout, err := m.GetDurationRequired(ctx, "timeout")
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, timeout)
+
+	raw, err := m.GetJsonRequired(ctx, "raw")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": 1}`, string(raw))
+
+	var missing *types.MissingPropertyError
+	_, err = m.GetBooleanRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+	_, err = m.GetStringRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+	_, err = m.GetIntegerRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+	_, err = m.GetFloatRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+	_, err = m.GetDurationRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+	_, err = m.GetJsonRequired(ctx, "absent")
+	assert.ErrorAs(t, err, &missing)
+
+	_, err = m.GetIntegerRequired(ctx, "bad")
+	assert.Error(t, err)
+	assert.False(t, errors.As(err, &missing))
+}
+
+func TestMapRemoveProperty(t *testing.T) {
+	ctx := context.Background()
+	m := Map{"a": 1, "db": Map{"host": "a", "port": 80}}
+
+	require.NoError(t, m.RemoveProperty(ctx, "$.db.host"))
+	require.NoError(t, m.RemoveProperty(ctx, "a"))
+	require.NoError(t, m.RemoveProperty(ctx, "$.missing.key"))
+	assert.Equal(t, Map{"db": Map{"port": 80}}, m)
+}
@@ -0,0 +1,69 @@
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// IsNDJSON is a helper function to determine if a JSON document is newline delimited JSON.
+func IsNDJSON(data []byte) (bool, error) {
+	if len(data) == 0 {
+		return false, nil
+	}
+	tp, err := TypeOf(bytes.NewBuffer(data))
+	if err != nil {
+		return false, fmt.Errorf("JsonType: %w", err)
+	}
+	return tp == KindNDJSON, nil
+}
+
+// NewMapsFromNDJSON is constructor for creating Maps from newline delimited JSON.
+// Blank lines are ignored. Errors contain number of the line.
+func NewMapsFromNDJSON(data []byte) ([]Map, error) {
+	dec := NewMapDecoderWithFormat(bytes.NewReader(data), FormatNDJSON)
+	var res []Map
+	for {
+		m, err := dec.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return res, nil
+			}
+			return nil, err
+		}
+		res = append(res, m)
+	}
+}
+
+// MarshalNDJSON is a helper function to marshal Maps into newline delimited JSON.
+func MarshalNDJSON(list []Map) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewMapEncoder(&buf, FormatNDJSON)
+	for i, m := range list {
+		err := enc.Encode(m)
+		if err != nil {
+			return nil, fmt.Errorf("Encode [%d]: %w", i, err)
+		}
+	}
+	err := enc.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UpdateAllNDJSON is a helper function to update a newline delimited list of JSON documents.
+// See UpdateAll for more information.
+func UpdateAllNDJSON(
+	doc []byte,
+	actions ...func(Map) error,
+) ([]byte, error) {
+	var buf bytes.Buffer
+	dec := NewMapDecoderWithFormat(bytes.NewReader(doc), FormatNDJSON)
+	err := updateAllStream(dec, &buf, actions...)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
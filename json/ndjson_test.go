@@ -0,0 +1,48 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNDJSON(t *testing.T) {
+	ctx := context.Background()
+	doc := []byte("{\"id\": 1}\n\n{\"id\": 2, \"tags\": {\"a\": [1]}}\n")
+
+	ok, err := IsNDJSON(doc)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = IsNDJSON([]byte(`{"id": {"a": 1}}`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	list, err := NewMapsFromNDJSON(doc)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, int64(2), list[1].ToInteger(ctx, "id", 0))
+
+	data, err := MarshalNDJSON(list[:1])
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":1}\n", string(data))
+
+	data, err = UpdateAllNDJSON(doc, WithRemove("tags"), WithValue("ok", true))
+	require.NoError(t, err)
+	list, err = NewMapsFromNDJSON(data)
+	require.NoError(t, err)
+	assert.Equal(t, []Map{{"id": Number("1"), "ok": true}, {"id": Number("2"), "ok": true}}, list)
+
+	_, err = NewMapsFromNDJSON([]byte("{\"id\": 1}\n{\"id\": \n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = UpdateAllNDJSON(doc, func(m Map) error {
+		if m.ToInteger(ctx, "id", 0) == 2 {
+			return errors.New("bad")
+		}
+		return nil
+	})
+	assert.ErrorContains(t, err, "line 3")
+}
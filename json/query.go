@@ -0,0 +1,575 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/adverax/types"
+	"github.com/adverax/types/convert"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Query is builder of the in-process queries over list of Maps.
+// Source rows are never modified. Errors are deferred until Rows is called.
+// Example:
+//
+//	rows, err := From(list).
+//		Where(Eq("$.status", "active")).
+//		GroupBy([]string{"country"}, Count("total"), Sum("amount", "$.order.amount")).
+//		OrderBy(Desc("amount")).
+//		Limit(10).
+//		Rows()
+type Query struct {
+	rows []Map
+	err  error
+}
+
+// Rows returns result of the query.
+func (that *Query) Rows() ([]Map, error) {
+	if that.err != nil {
+		return nil, that.err
+	}
+	return that.rows, nil
+}
+
+// Where filters rows by predicate.
+func (that *Query) Where(predicate func(Map) bool) *Query {
+	if that.err != nil {
+		return that
+	}
+	rows := make([]Map, 0, len(that.rows))
+	for _, row := range that.rows {
+		if predicate(row) {
+			rows = append(rows, row)
+		}
+	}
+	return &Query{rows: rows}
+}
+
+// Select projects rows to the specified paths. Nested paths keep nesting.
+func (that *Query) Select(paths ...string) *Query {
+	fields := make(map[string]string, len(paths))
+	for _, path := range paths {
+		fields[path] = path
+	}
+	return that.Project(fields)
+}
+
+// Project projects rows by map of the new paths to the source paths.
+// Missing values are skipped.
+// Example: Project(map[string]string{"host": "$.db.host"})
+func (that *Query) Project(fields map[string]string) *Query {
+	if that.err != nil {
+		return that
+	}
+	ctx := context.Background()
+	rows := make([]Map, len(that.rows))
+	for i, row := range that.rows {
+		res := make(Map, len(fields))
+		for to, from := range fields {
+			val, err := row.GetProperty(ctx, from)
+			if err != nil {
+				if errors.Is(err, types.GetErrNoMatch()) {
+					continue
+				}
+				return &Query{err: fmt.Errorf("GetProperty: %w", err)}
+			}
+			err = res.SetProperty(ctx, to, val)
+			if err != nil {
+				return &Query{err: fmt.Errorf("SetProperty: %w", err)}
+			}
+		}
+		rows[i] = res
+	}
+	return &Query{rows: rows}
+}
+
+// Rename renames top level field of the each row.
+func (that *Query) Rename(from, to string) *Query {
+	if that.err != nil {
+		return that
+	}
+	rows := make([]Map, len(that.rows))
+	for i, row := range that.rows {
+		res := make(Map, len(row))
+		for k, v := range row {
+			if k == from {
+				k = to
+			}
+			res[k] = v
+		}
+		rows[i] = res
+	}
+	return &Query{rows: rows}
+}
+
+// SortKey is key of the ordering.
+type SortKey struct {
+	Path string
+	Desc bool
+}
+
+// Asc returns ascending sort key.
+func Asc(path string) SortKey {
+	return SortKey{Path: path}
+}
+
+// Desc returns descending sort key.
+func Desc(path string) SortKey {
+	return SortKey{Path: path, Desc: true}
+}
+
+// OrderBy sorts rows by the keys (stable). Numbers are compared as numbers,
+// other values as strings, missing values go first.
+func (that *Query) OrderBy(keys ...SortKey) *Query {
+	if that.err != nil {
+		return that
+	}
+	ctx := context.Background()
+	rows := make([]Map, len(that.rows))
+	copy(rows, that.rows)
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, key := range keys {
+			a, _ := rows[i].GetProperty(ctx, key.Path)
+			b, _ := rows[j].GetProperty(ctx, key.Path)
+			res := compareValues(a, b)
+			if res == 0 {
+				continue
+			}
+			if key.Desc {
+				return res > 0
+			}
+			return res < 0
+		}
+		return false
+	})
+	return &Query{rows: rows}
+}
+
+// Distinct removes duplicated rows. If paths are specified, rows are compared by them only
+// and the first row of the each group is kept.
+func (that *Query) Distinct(paths ...string) *Query {
+	if that.err != nil {
+		return that
+	}
+	seen := make(map[string]struct{}, len(that.rows))
+	rows := make([]Map, 0, len(that.rows))
+	for _, row := range that.rows {
+		key, err := rowKey(row, paths)
+		if err != nil {
+			return &Query{err: err}
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		rows = append(rows, row)
+	}
+	return &Query{rows: rows}
+}
+
+// Offset skips the first n rows. Negative n is treated as zero.
+func (that *Query) Offset(n int) *Query {
+	if that.err != nil || n <= 0 {
+		return that
+	}
+	if n >= len(that.rows) {
+		return &Query{rows: []Map{}}
+	}
+	return &Query{rows: that.rows[n:]}
+}
+
+// Limit keeps at most n rows. Negative n is treated as zero.
+func (that *Query) Limit(n int) *Query {
+	if that.err != nil || n >= len(that.rows) {
+		return that
+	}
+	return &Query{rows: that.rows[:max(n, 0)]}
+}
+
+// Join is inner join of the rows with the right list by equality of the keys.
+// Fields of the right row are added to the copy of the left row, left fields win.
+func (that *Query) Join(right []Map, leftPath, rightPath string) *Query {
+	if that.err != nil {
+		return that
+	}
+	ctx := context.Background()
+	index := make(map[string][]Map, len(right))
+	for _, row := range right {
+		val, err := row.GetProperty(ctx, rightPath)
+		if err != nil {
+			continue
+		}
+		key, err := valueKey(val)
+		if err != nil {
+			return &Query{err: err}
+		}
+		index[key] = append(index[key], row)
+	}
+
+	var rows []Map
+	for _, row := range that.rows {
+		val, err := row.GetProperty(ctx, leftPath)
+		if err != nil {
+			continue
+		}
+		key, err := valueKey(val)
+		if err != nil {
+			return &Query{err: err}
+		}
+		for _, r := range index[key] {
+			res := row.Clone()
+			res.ExpandBy(r)
+			rows = append(rows, res)
+		}
+	}
+	return &Query{rows: rows}
+}
+
+// Aggregate is aggregate function of the group.
+type Aggregate struct {
+	Name string
+	Path string
+	Init func() Accumulator
+}
+
+// Accumulator accumulates values of the group.
+type Accumulator interface {
+	Add(value interface{}) error
+	Result() interface{}
+}
+
+// GroupBy groups rows by the paths and calculates aggregates for the each group.
+// Result rows contain group keys and aggregates in the order of the first appearance of the groups.
+func (that *Query) GroupBy(paths []string, aggregates ...Aggregate) *Query {
+	if that.err != nil {
+		return that
+	}
+	ctx := context.Background()
+
+	type group struct {
+		row  Map
+		accs []Accumulator
+	}
+
+	groups := make(map[string]*group)
+	var order []*group
+	for _, row := range that.rows {
+		key, err := rowKey(row, paths)
+		if err != nil {
+			return &Query{err: err}
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{row: make(Map)}
+			for _, path := range paths {
+				if val, err := row.GetProperty(ctx, path); err == nil {
+					_ = g.row.SetProperty(ctx, path, val)
+				}
+			}
+			for _, aggregate := range aggregates {
+				g.accs = append(g.accs, aggregate.Init())
+			}
+			groups[key] = g
+			order = append(order, g)
+		}
+
+		for i, aggregate := range aggregates {
+			var val interface{} = row
+			if aggregate.Path != "" {
+				var err error
+				val, err = row.GetProperty(ctx, aggregate.Path)
+				if err != nil {
+					if errors.Is(err, types.GetErrNoMatch()) {
+						continue
+					}
+					return &Query{err: fmt.Errorf("GetProperty: %w", err)}
+				}
+			}
+			if val == nil {
+				continue
+			}
+			err := g.accs[i].Add(val)
+			if err != nil {
+				return &Query{err: fmt.Errorf("aggregate %q: %w", aggregate.Name, err)}
+			}
+		}
+	}
+
+	rows := make([]Map, len(order))
+	for i, g := range order {
+		for j, aggregate := range aggregates {
+			g.row[aggregate.Name] = g.accs[j].Result()
+		}
+		rows[i] = g.row
+	}
+	return &Query{rows: rows}
+}
+
+// From is constructor for creating Query.
+func From(rows []Map) *Query {
+	return &Query{rows: rows}
+}
+
+// Eq returns predicate, that checks equality of the value by path.
+// Numbers are compared by value.
+func Eq(path string, value interface{}) func(Map) bool {
+	return func(row Map) bool {
+		val, err := row.GetProperty(context.Background(), path)
+		if err != nil {
+			return false
+		}
+		return convert.DeepEqual(val, value, convert.EqualOptions{NormalizeNumbers: true})
+	}
+}
+
+// Has returns predicate, that checks existence of the value by path.
+func Has(path string) func(Map) bool {
+	return func(row Map) bool {
+		return row.Contains(path)
+	}
+}
+
+// Not returns negation of the predicate.
+func Not(predicate func(Map) bool) func(Map) bool {
+	return func(row Map) bool {
+		return !predicate(row)
+	}
+}
+
+// Count counts rows of the group or non-null values, if path is specified.
+func Count(name string, path ...string) Aggregate {
+	return Aggregate{
+		Name: name,
+		Path: firstPath(path),
+		Init: func() Accumulator { return &countAccumulator{} },
+	}
+}
+
+// Sum calculates sum of the values.
+func Sum(name, path string) Aggregate {
+	return Aggregate{Name: name, Path: path, Init: func() Accumulator { return &sumAccumulator{} }}
+}
+
+// Avg calculates average of the values.
+func Avg(name, path string) Aggregate {
+	return Aggregate{Name: name, Path: path, Init: func() Accumulator { return &sumAccumulator{avg: true} }}
+}
+
+// Min calculates minimum of the values.
+func Min(name, path string) Aggregate {
+	return Aggregate{Name: name, Path: path, Init: func() Accumulator { return &extremumAccumulator{sign: -1} }}
+}
+
+// Max calculates maximum of the values.
+func Max(name, path string) Aggregate {
+	return Aggregate{Name: name, Path: path, Init: func() Accumulator { return &extremumAccumulator{sign: 1} }}
+}
+
+type countAccumulator struct {
+	count int64
+}
+
+func (that *countAccumulator) Add(value interface{}) error {
+	that.count++
+	return nil
+}
+
+func (that *countAccumulator) Result() interface{} {
+	return that.count
+}
+
+type sumAccumulator struct {
+	avg   bool
+	sum   float64
+	count int64
+}
+
+func (that *sumAccumulator) Add(value interface{}) error {
+	v, ok := types.Type.Float.TryCast(value)
+	if !ok {
+		return fmt.Errorf("can not convert value %v into float", value)
+	}
+	that.sum += v
+	that.count++
+	return nil
+}
+
+func (that *sumAccumulator) Result() interface{} {
+	if !that.avg {
+		return that.sum
+	}
+	if that.count == 0 {
+		return nil
+	}
+	return that.sum / float64(that.count)
+}
+
+type extremumAccumulator struct {
+	sign  float64
+	value float64
+	has   bool
+}
+
+func (that *extremumAccumulator) Add(value interface{}) error {
+	v, ok := types.Type.Float.TryCast(value)
+	if !ok {
+		return fmt.Errorf("can not convert value %v into float", value)
+	}
+	if !that.has || v*that.sign > that.value*that.sign {
+		that.value = v
+		that.has = true
+	}
+	return nil
+}
+
+func (that *extremumAccumulator) Result() interface{} {
+	if !that.has {
+		return nil
+	}
+	return that.value
+}
+
+func firstPath(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	return paths[0]
+}
+
+// compareValues compares values. Numbers are compared as numbers, other values as strings.
+func compareValues(a, b interface{}) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		default:
+			return 1
+		}
+	}
+
+	if isNumeric(a) && isNumeric(b) {
+		af, aok := types.Type.Float.TryCast(a)
+		bf, bok := types.Type.Float.TryCast(b)
+		if aok && bok {
+			switch {
+			case af < bf:
+				return -1
+			case af > bf:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	as := types.Type.String.Cast(a, fmt.Sprint(a))
+	bs := types.Type.String.Cast(b, fmt.Sprint(b))
+	switch {
+	case as < bs:
+		return -1
+	case as > bs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isNumeric(value interface{}) bool {
+	return types.Type.Integer.Is(value) || types.Type.Float.Is(value)
+}
+
+// rowKey returns key of the row by values of the paths or by whole row.
+func rowKey(row Map, paths []string) (string, error) {
+	if len(paths) == 0 {
+		return valueKey(row)
+	}
+	ctx := context.Background()
+	values := make([]interface{}, len(paths))
+	for i, path := range paths {
+		values[i], _ = row.GetProperty(ctx, path)
+	}
+	return valueKey(values)
+}
+
+// valueKey returns key of the value for grouping and joining.
+// Numbers are normalized, so keys are consistent with Eq (1 and 1.0 have the same key).
+func valueKey(value interface{}) (string, error) {
+	var sb strings.Builder
+	err := writeValueKey(&sb, value)
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func writeValueKey(sb *strings.Builder, value interface{}) error {
+	if r, ok := convert.ConvertToRat(value); ok {
+		sb.WriteString(r.RatString())
+		return nil
+	}
+
+	switch v := value.(type) {
+	case Map:
+		return writeMapKey(sb, v)
+	case map[string]interface{}:
+		return writeMapKey(sb, v)
+	case []Map:
+		sb.WriteByte('[')
+		for i, item := range v {
+			if i != 0 {
+				sb.WriteByte(',')
+			}
+			if err := writeMapKey(sb, item); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+		return nil
+	case []interface{}:
+		sb.WriteByte('[')
+		for i, item := range v {
+			if i != 0 {
+				sb.WriteByte(',')
+			}
+			if err := writeValueKey(sb, item); err != nil {
+				return err
+			}
+		}
+		sb.WriteByte(']')
+		return nil
+	}
+
+	data, err := ConfigSorted.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+	sb.Write(data)
+	return nil
+}
+
+func writeMapKey(sb *strings.Builder, m map[string]interface{}) error {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb.WriteByte('{')
+	for i, k := range keys {
+		if i != 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Quote(k))
+		sb.WriteByte(':')
+		if err := writeValueKey(sb, m[k]); err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,69 @@
+package json
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	orders, err := NewMaps([]byte(`[
+		{"id": 1, "user": 10, "status": "paid", "amount": 100},
+		{"id": 2, "user": 20, "status": "paid", "amount": 50.5},
+		{"id": 3, "user": 10, "status": "new", "amount": 7},
+		{"id": 4, "user": 10, "status": "paid", "amount": 30}
+	]`))
+	require.NoError(t, err)
+	users, err := NewMaps([]byte(`[{"uid": 10, "name": "bob"}, {"uid": 20, "name": "ann"}]`))
+	require.NoError(t, err)
+
+	rows, err := From(orders).
+		Where(Eq("status", "paid")).
+		Join(users, "user", "uid").
+		GroupBy([]string{"name"}, Count("count"), Sum("total", "amount"), Max("max", "amount"), Avg("avg", "amount")).
+		OrderBy(Asc("total")).
+		Rows()
+	require.NoError(t, err)
+	assert.Equal(t, []Map{
+		{"name": "ann", "count": int64(1), "total": 50.5, "max": 50.5, "avg": 50.5},
+		{"name": "bob", "count": int64(2), "total": 130.0, "max": 100.0, "avg": 65.0},
+	}, rows)
+
+	rows, err = From(orders).
+		OrderBy(Asc("user"), Desc("amount")).
+		Project(map[string]string{"$.order.id": "id"}).
+		Offset(1).
+		Limit(2).
+		Rows()
+	require.NoError(t, err)
+	assert.Equal(t, []Map{
+		{"order": Map{"id": Number("4")}},
+		{"order": Map{"id": Number("3")}},
+	}, rows)
+
+	rows, err = From(orders).Distinct("user").Select("user").Rename("user", "u").Rows()
+	require.NoError(t, err)
+	assert.Equal(t, []Map{{"u": Number("10")}, {"u": Number("20")}}, rows)
+
+	rows, err = From(orders).Offset(-1).Limit(-1).Rows()
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	rows, err = From(orders).Offset(-1).Rows()
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
+
+	// Numeric keys are normalized like in Eq
+	mixed := []Map{{"k": Number("1.0"), "v": 1}, {"k": 1, "v": 2}, {"k": "1", "v": 3}}
+	rows, err = From(mixed).GroupBy([]string{"k"}, Count("n")).OrderBy(Asc("n")).Rows()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, int64(2), rows[1]["n"])
+	rows, err = From(mixed[:1]).Join([]Map{{"id": int64(1), "name": "x"}}, "k", "id").Rows()
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "x", rows[0]["name"])
+	assert.True(t, Eq("k", 1)(mixed[0]))
+
+	_, err = From(orders).GroupBy(nil, Sum("s", "status")).Rows()
+	assert.Error(t, err)
+}
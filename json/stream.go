@@ -0,0 +1,344 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamFormat is format of the stream of the JSON documents.
+type StreamFormat int
+
+const (
+	// FormatArray is JSON array of objects.
+	FormatArray StreamFormat = iota
+	// FormatNDJSON is newline delimited JSON objects (JSON Lines).
+	FormatNDJSON
+)
+
+// MapDecoder reads objects from the stream one at a time.
+// Both JSON arrays and NDJSON are supported; the format is detected by the first character.
+// Detected NDJSON is decoded as sequence of objects, so objects may span several lines,
+// explicit FormatNDJSON (see NewMapDecoderWithFormat) requires exactly one object per line.
+// Example:
+//
+//	dec := NewMapDecoder(r)
+//	for {
+//		m, err := dec.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+type MapDecoder struct {
+	reader   *bufio.Reader
+	decoder  *json.Decoder
+	lines    *lineReader
+	format   StreamFormat
+	detected bool
+	finished bool
+	fixed    bool
+	line     int
+}
+
+// Line returns number of the line of the last object read from NDJSON stream.
+// For detected NDJSON it is the line, where the object starts.
+func (that *MapDecoder) Line() int {
+	return that.line
+}
+
+// Format returns format of the stream. Empty stream is treated as array.
+func (that *MapDecoder) Format() (StreamFormat, error) {
+	if that.detected {
+		return that.format, nil
+	}
+
+	c, skipped, err := peekNonSpace(that.reader)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("Peek: %w", err)
+	}
+
+	that.detected = true
+	that.lines = &lineReader{reader: that.reader, base: skipped}
+	that.decoder = json.NewDecoder(that.lines)
+	that.decoder.UseNumber()
+	if c == '{' && !that.fixed {
+		that.format = FormatNDJSON
+		return that.format, nil
+	}
+
+	that.format = FormatArray
+	return that.format, that.openArray(err)
+}
+
+func (that *MapDecoder) openArray(err error) error {
+	if errors.Is(err, io.EOF) {
+		that.finished = true
+		return nil
+	}
+
+	t, err := that.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("Token: %w", err)
+	}
+	if d, ok := t.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("unexpected token %v, expected array or object", t)
+	}
+	return nil
+}
+
+// Next returns next object of the stream or io.EOF at the end of stream.
+func (that *MapDecoder) Next() (Map, error) {
+	format, err := that.Format()
+	if err != nil {
+		return nil, err
+	}
+	if that.finished {
+		return nil, io.EOF
+	}
+
+	if format == FormatNDJSON && that.fixed {
+		return that.nextLine()
+	}
+
+	if format == FormatArray && !that.decoder.More() {
+		that.finished = true
+		if _, err := that.decoder.Token(); err != nil {
+			return nil, fmt.Errorf("Token: %w", err)
+		}
+		if _, err := that.decoder.Token(); !errors.Is(err, io.EOF) {
+			return nil, errors.New("unexpected data after array")
+		}
+		return nil, io.EOF
+	}
+
+	if format == FormatNDJSON {
+		// More skips white spaces, so offset points to the start of the next object
+		that.decoder.More()
+		that.line = that.lines.lineAt(that.decoder.InputOffset() + 1)
+	}
+
+	var res map[string]interface{}
+	err = that.decoder.Decode(&res)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			that.finished = true
+			return nil, io.EOF
+		}
+		if format == FormatNDJSON {
+			return nil, fmt.Errorf("line %d: Decode: %w", that.line, err)
+		}
+		return nil, fmt.Errorf("Decode: %w", err)
+	}
+
+	return NewMapFromStruct(res), nil
+}
+
+func (that *MapDecoder) nextLine() (Map, error) {
+	for {
+		line, err := that.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("line %d: %w", that.line+1, err)
+		}
+		if len(line) == 0 && errors.Is(err, io.EOF) {
+			that.finished = true
+			return nil, io.EOF
+		}
+
+		that.line++
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		m, err := NewMap(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", that.line, err)
+		}
+		return m, nil
+	}
+}
+
+// NewMapDecoder is constructor for creating MapDecoder.
+func NewMapDecoder(r io.Reader) *MapDecoder {
+	return &MapDecoder{reader: bufio.NewReader(r)}
+}
+
+// NewMapDecoderWithFormat is constructor for creating MapDecoder with known format.
+func NewMapDecoderWithFormat(r io.Reader, format StreamFormat) *MapDecoder {
+	return &MapDecoder{
+		reader:   bufio.NewReader(r),
+		format:   format,
+		detected: format == FormatNDJSON,
+		fixed:    true,
+	}
+}
+
+// MapEncoder writes objects into the stream one at a time.
+// Close must be called to complete the array.
+type MapEncoder struct {
+	writer io.Writer
+	format StreamFormat
+	count  int
+	closed bool
+}
+
+// Encode writes object into the stream.
+func (that *MapEncoder) Encode(m Map) error {
+	if that.closed {
+		return errors.New("encoder is closed")
+	}
+
+	data, err := Marshal(m)
+	if err != nil {
+		return fmt.Errorf("Marshal: %w", err)
+	}
+
+	var prefix, suffix string
+	switch that.format {
+	case FormatArray:
+		prefix = ","
+		if that.count == 0 {
+			prefix = "["
+		}
+	case FormatNDJSON:
+		suffix = "\n"
+	}
+
+	if _, err := io.WriteString(that.writer, prefix); err != nil {
+		return err
+	}
+	if _, err := that.writer.Write(data); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(that.writer, suffix); err != nil {
+		return err
+	}
+
+	that.count++
+	return nil
+}
+
+// Close completes the stream. It does not close underlying writer.
+func (that *MapEncoder) Close() error {
+	if that.closed {
+		return nil
+	}
+	that.closed = true
+
+	if that.format != FormatArray {
+		return nil
+	}
+	if that.count == 0 {
+		_, err := io.WriteString(that.writer, "[]")
+		return err
+	}
+	_, err := io.WriteString(that.writer, "]")
+	return err
+}
+
+// NewMapEncoder is constructor for creating MapEncoder.
+func NewMapEncoder(w io.Writer, format StreamFormat) *MapEncoder {
+	return &MapEncoder{writer: w, format: format}
+}
+
+// UpdateAllStream is streaming variant of UpdateAll.
+// It reads objects one at a time, applies actions and writes results in the same format.
+func UpdateAllStream(
+	r io.Reader,
+	w io.Writer,
+	actions ...func(Map) error,
+) error {
+	return updateAllStream(NewMapDecoder(r), w, actions...)
+}
+
+func updateAllStream(
+	dec *MapDecoder,
+	w io.Writer,
+	actions ...func(Map) error,
+) error {
+	format, err := dec.Format()
+	if err != nil {
+		return fmt.Errorf("Format: %w", err)
+	}
+
+	enc := NewMapEncoder(w, format)
+	for i := 0; ; i++ {
+		m, err := dec.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("Next: %w", err)
+		}
+
+		for _, action := range actions {
+			err = action(m)
+			if err != nil {
+				if format == FormatNDJSON {
+					return fmt.Errorf("line %d: action: %w", dec.Line(), err)
+				}
+				return fmt.Errorf("action [%d]: %w", i, err)
+			}
+		}
+
+		err = enc.Encode(m)
+		if err != nil {
+			return fmt.Errorf("Encode: %w", err)
+		}
+	}
+
+	return enc.Close()
+}
+
+// peekNonSpace skips white spaces and returns next character with count of skipped lines.
+func peekNonSpace(r *bufio.Reader) (byte, int, error) {
+	lines := 0
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, lines, err
+		}
+		switch c {
+		case '\n':
+			lines++
+			continue
+		case ' ', '\t', '\r':
+			continue
+		}
+		return c, lines, r.UnreadByte()
+	}
+}
+
+// lineReader tracks positions of line breaks for reporting lines of the decoded objects.
+type lineReader struct {
+	reader io.Reader
+	offset int64
+	breaks []int64
+	base   int
+}
+
+func (that *lineReader) Read(p []byte) (int, error) {
+	n, err := that.reader.Read(p)
+	for i, c := range p[:n] {
+		if c == '\n' {
+			that.breaks = append(that.breaks, that.offset+int64(i))
+		}
+	}
+	that.offset += int64(n)
+	return n, err
+}
+
+// lineAt returns number of the line, that contains byte before the offset.
+// Offsets must not decrease between calls.
+func (that *lineReader) lineAt(offset int64) int {
+	i := 0
+	for i < len(that.breaks) && that.breaks[i] < offset-1 {
+		i++
+	}
+	that.base += i
+	that.breaks = that.breaks[i:]
+	return that.base + 1
+}
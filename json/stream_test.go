@@ -0,0 +1,123 @@
+package json
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestMapDecoder(t *testing.T) {
+	type Test struct {
+		src    string
+		format StreamFormat
+		ids    []string
+	}
+
+	tests := map[string]Test{
+		"Array": {
+			src:    ` [{"id": 1}, {"id": 2}] `,
+			format: FormatArray,
+			ids:    []string{"1", "2"},
+		},
+		"Empty array": {
+			src:    `[]`,
+			format: FormatArray,
+		},
+		"Empty input": {
+			src:    ``,
+			format: FormatArray,
+		},
+		"NDJSON": {
+			src:    "{\"id\": 1}\n{\"id\": 2}\n",
+			format: FormatNDJSON,
+			ids:    []string{"1", "2"},
+		},
+		"Pretty objects": {
+			src:    "{\n  \"id\": 1\n}\n{\n  \"id\": 2\n}",
+			format: FormatNDJSON,
+			ids:    []string{"1", "2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dec := NewMapDecoder(strings.NewReader(test.src))
+			format, err := dec.Format()
+			require.NoError(t, err)
+			assert.Equal(t, test.format, format)
+
+			var ids []string
+			for {
+				m, err := dec.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				ids = append(ids, m.ToString(context.Background(), "id", ""))
+			}
+			assert.Equal(t, test.ids, ids)
+		})
+	}
+
+	_, err := NewMapDecoder(strings.NewReader(`"abc"`)).Next()
+	assert.Error(t, err)
+
+	for _, src := range []string{`[{"a":1}] garbage`, `[] []`, `[{"a":1}]]`} {
+		dec := NewMapDecoder(strings.NewReader(src))
+		var err error
+		for err == nil {
+			_, err = dec.Next()
+		}
+		assert.False(t, errors.Is(err, io.EOF), src)
+	}
+
+	dec := NewMapDecoder(strings.NewReader("\n{\"id\": 1}\n{\n\"id\": 2\n}\n"))
+	_, err = dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 2, dec.Line())
+	_, err = dec.Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, dec.Line())
+
+	for src, line := range map[string]string{
+		"{\"a\":1}\n{bad}\n":                         "line 2:",
+		"{\"a\":1}\n{\"a\":2}\n\n{\"a\":3}\n{\"a\":": "line 5:",
+	} {
+		dec := NewMapDecoder(strings.NewReader(src))
+		var err error
+		for err == nil {
+			_, err = dec.Next()
+		}
+		assert.ErrorContains(t, err, line, src)
+	}
+
+	// Explicit NDJSON requires one object per line
+	_, err = NewMapsFromNDJSON([]byte("{\n\"id\": 1\n}"))
+	assert.ErrorContains(t, err, "line 1")
+}
+
+func TestUpdateAllStream(t *testing.T) {
+	var out bytes.Buffer
+	err := UpdateAllStream(
+		strings.NewReader(`[{"id": 1}, {"id": 2}]`),
+		&out,
+		WithValue("ok", true),
+	)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id":1,"ok":true},{"id":2,"ok":true}]`, out.String())
+
+	out.Reset()
+	err = UpdateAllStream(strings.NewReader("{\"id\": 1}\n{\"id\": 2}"), &out, WithRemove("id"))
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n{}\n", out.String())
+
+	out.Reset()
+	enc := NewMapEncoder(&out, FormatArray)
+	require.NoError(t, enc.Close())
+	assert.Equal(t, "[]", out.String())
+}
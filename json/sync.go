@@ -0,0 +1,71 @@
+package json
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SyncMap is concurrency-safe Map with copy-on-write semantics.
+// Readers work with immutable snapshot without locks,
+// writers are serialized and replace snapshot by modified copy.
+type SyncMap struct {
+	mx       sync.Mutex
+	snapshot atomic.Pointer[Map]
+}
+
+// Snapshot returns current snapshot. Snapshot must not be modified.
+// Zero SyncMap has empty snapshot.
+func (that *SyncMap) Snapshot() Map {
+	m := that.snapshot.Load()
+	if m == nil {
+		return Map{}
+	}
+	return *m
+}
+
+func (that *SyncMap) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	return that.Snapshot().GetProperty(ctx, name)
+}
+
+func (that *SyncMap) SetProperty(
+	ctx context.Context,
+	name string,
+	value interface{},
+) error {
+	return that.Update(func(m Map) error {
+		return m.SetProperty(ctx, name, value)
+	})
+}
+
+// Update is atomic transaction. Action receives private copy of the map.
+// If action succeeds, copy replaces current snapshot, otherwise it is discarded.
+func (that *SyncMap) Update(action func(Map) error) error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	m := that.Snapshot().Clone()
+	err := action(m)
+	if err != nil {
+		return fmt.Errorf("action: %w", err)
+	}
+
+	that.snapshot.Store(&m)
+	return nil
+}
+
+// NewSyncMap is constructor for creating SyncMap from Map.
+// Map is copied, so it can be used by caller after that.
+func NewSyncMap(m Map) *SyncMap {
+	if m == nil {
+		m = make(Map)
+	}
+	res := &SyncMap{}
+	cp := m.Clone()
+	res.snapshot.Store(&cp)
+	return res
+}
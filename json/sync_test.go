@@ -0,0 +1,61 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+func TestSyncMap(t *testing.T) {
+	ctx := context.Background()
+	m := NewSyncMap(Map{"db": Map{"host": "a"}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = m.SetProperty(ctx, fmt.Sprintf("$.scope%d.key%d", i, j), j)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, _ = m.GetProperty(ctx, "$.db.host")
+				_ = m.Snapshot().ToMap(ctx, "db")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(99), m.Snapshot().ToInteger(ctx, "$.scope7.key99", 0))
+
+	err := m.Update(func(m Map) error {
+		_ = m.SetProperty(ctx, "$.db.host", "b")
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "a", m.Snapshot().ToString(ctx, "$.db.host", ""))
+
+	snapshot := m.Snapshot()
+	require.NoError(t, m.SetProperty(ctx, "$.db.host", "c"))
+	assert.Equal(t, "a", snapshot.ToString(ctx, "$.db.host", ""))
+	assert.Equal(t, "c", m.Snapshot().ToString(ctx, "$.db.host", ""))
+}
+
+func TestSyncMap_Zero(t *testing.T) {
+	ctx := context.Background()
+	var m SyncMap
+
+	assert.Empty(t, m.Snapshot())
+	_, err := m.GetProperty(ctx, "$.db.host")
+	assert.Error(t, err)
+
+	require.NoError(t, m.SetProperty(ctx, "$.db.host", "a"))
+	assert.Equal(t, "a", m.Snapshot().ToString(ctx, "$.db.host", ""))
+}
@@ -63,7 +63,7 @@ func UpdateAll(
 	for i, row := range rows {
 		rows[i], err = Update(row, actions...)
 		if err != nil {
-			return nil, fmt.Errorf("Update: %w", err)
+			return nil, fmt.Errorf("Update [%d]: %w", i, err)
 		}
 	}
 
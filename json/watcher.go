@@ -0,0 +1,158 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher is hot-reloading Map, that loaded from layered files (see NewMapFromFiles).
+// Readers obtain current snapshot without locks. Snapshot must not be modified.
+type Watcher struct {
+	mx       sync.Mutex
+	files    []string
+	interval time.Duration
+	validate func(Map) error
+	stamps   []fileStamp
+	snapshot atomic.Pointer[Map]
+	lastErr  atomic.Pointer[error]
+}
+
+// Snapshot returns current immutable snapshot.
+func (that *Watcher) Snapshot() Map {
+	return *that.snapshot.Load()
+}
+
+// LastError returns error of the last reload or nil.
+func (that *Watcher) LastError() error {
+	if err := that.lastErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (that *Watcher) GetProperty(
+	ctx context.Context,
+	name string,
+) (interface{}, error) {
+	return that.Snapshot().GetProperty(ctx, name)
+}
+
+// Reload loads files unconditionally. Broken or invalid documents are rejected
+// and the previous snapshot is kept.
+func (that *Watcher) Reload() error {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	return that.reload(that.stat())
+}
+
+// Run polls files until context is done and reloads snapshot, when files are changed.
+func (that *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(that.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			_, _ = that.Check()
+		}
+	}
+}
+
+// Check reloads snapshot, if files are changed since the last reload.
+func (that *Watcher) Check() (changed bool, err error) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	stamps := that.stat()
+	if isEqualStamps(stamps, that.stamps) {
+		return false, nil
+	}
+
+	err = that.reload(stamps)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (that *Watcher) reload(stamps []fileStamp) error {
+	// Remember stamps even for broken documents to avoid reloading them on each tick.
+	that.stamps = stamps
+
+	m, err := NewMapFromFiles(that.files...)
+	if err == nil && that.validate != nil {
+		err = that.validate(m)
+	}
+	if err != nil {
+		err = fmt.Errorf("reload: %w", err)
+		that.lastErr.Store(&err)
+		return err
+	}
+
+	that.snapshot.Store(&m)
+	that.lastErr.Store(nil)
+	return nil
+}
+
+func (that *Watcher) stat() []fileStamp {
+	stamps := make([]fileStamp, len(that.files))
+	for i, file := range that.files {
+		info, err := os.Stat(file)
+		if err == nil {
+			stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+func isEqualStamps(a, b []fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
+
+// NewWatcher is constructor for creating Watcher.
+// Validate is optional callback for checking of the loaded documents.
+// Initial loading must be successful.
+// Example: NewWatcher(time.Second, nil, "config.json", "config.local.json")
+func NewWatcher(
+	interval time.Duration,
+	validate func(Map) error,
+	files ...string,
+) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, errors.New("interval must be positive")
+	}
+
+	w := &Watcher{
+		files:    files,
+		interval: interval,
+		validate: validate,
+	}
+
+	err := w.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
@@ -0,0 +1,70 @@
+package json
+
+import (
+	"context"
+	"errors"
+	"github.com/adverax/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.json")
+	local := filepath.Join(dir, "local.json")
+	require.NoError(t, os.WriteFile(base, []byte(`{"port": 80, "host": "a"}`), 0644))
+
+	validate := func(m Map) error {
+		port, err := m.GetInteger(ctx, "port", 0)
+		if err != nil {
+			return err
+		}
+		if port <= 0 {
+			return errors.New("invalid port")
+		}
+		return nil
+	}
+
+	w, err := NewWatcher(time.Millisecond, validate, local, base)
+	require.NoError(t, err)
+
+	port, err := types.Type.Integer.Get(ctx, w, "port", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(80), port)
+
+	changed, err := w.Check()
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	require.NoError(t, os.WriteFile(local, []byte(`{"port": 8080}`), 0644))
+	changed, err = w.Check()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(8080), w.Snapshot().ToInteger(ctx, "port", 0))
+	assert.Equal(t, "a", w.Snapshot().ToString(ctx, "host", ""))
+
+	require.NoError(t, os.WriteFile(local, []byte(`{"port": `), 0644))
+	_, err = w.Check()
+	assert.Error(t, err)
+	assert.Error(t, w.LastError())
+	assert.Equal(t, int64(8080), w.Snapshot().ToInteger(ctx, "port", 0))
+
+	require.NoError(t, os.WriteFile(local, []byte(`{"port": -1}`), 0644))
+	_, err = w.Check()
+	assert.Error(t, err)
+	assert.Equal(t, int64(8080), w.Snapshot().ToInteger(ctx, "port", 0))
+
+	require.NoError(t, os.WriteFile(local, []byte(`{"port": 9090}`), 0644))
+	ctx2, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	go func() { _ = w.Run(ctx2) }()
+	assert.Eventually(t, func() bool {
+		return w.Snapshot().ToInteger(ctx, "port", 0) == 9090
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, w.LastError())
+}
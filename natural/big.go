@@ -0,0 +1,94 @@
+package natural
+
+import (
+	"math/big"
+)
+
+// Big is arbitrary-precision natural fraction backed by big.Rat.
+// Big is immutable: all operations return new value. Zero value is 0.
+type Big struct {
+	rat *big.Rat
+}
+
+// Rat returns copy of the underlying rational number.
+func (a Big) Rat() *big.Rat {
+	return new(big.Rat).Set(a.get())
+}
+
+// Value converts Big into Value or returns NaN and ErrOverflow.
+func (a Big) Value() (Value, error) {
+	r := a.get()
+	if !r.Num().IsInt64() || !r.Denom().IsInt64() {
+		return NaN, ErrOverflow
+	}
+	return Value{Num: r.Num().Int64(), Div: r.Denom().Int64()}, nil
+}
+
+// String returns literal representation in the form "num/div".
+func (a Big) String() string {
+	return a.get().String()
+}
+
+// Float representation of fraction.
+func (a Big) Float() float64 {
+	f, _ := a.get().Float64()
+	return f
+}
+
+// Sign returns -1, 0 or 1.
+func (a Big) Sign() int {
+	return a.get().Sign()
+}
+
+// Cmp compares fractions and returns -1, 0 or 1.
+func (a Big) Cmp(b Big) int {
+	return a.get().Cmp(b.get())
+}
+
+// Add fractions
+func (a Big) Add(b Big) Big {
+	return Big{rat: new(big.Rat).Add(a.get(), b.get())}
+}
+
+// Subtract fractions
+func (a Big) Subtract(b Big) Big {
+	return Big{rat: new(big.Rat).Sub(a.get(), b.get())}
+}
+
+// Multiple fractions
+func (a Big) Multiple(b Big) Big {
+	return Big{rat: new(big.Rat).Mul(a.get(), b.get())}
+}
+
+// Divide fractions
+func (a Big) Divide(b Big) (Big, error) {
+	if b.Sign() == 0 {
+		return Big{}, ErrDivisionByZero
+	}
+	return Big{rat: new(big.Rat).Quo(a.get(), b.get())}, nil
+}
+
+// Negate returns negative fraction
+func (a Big) Negate() Big {
+	return Big{rat: new(big.Rat).Neg(a.get())}
+}
+
+func (a Big) get() *big.Rat {
+	if a.rat == nil {
+		return new(big.Rat)
+	}
+	return a.rat
+}
+
+// NewBig converts Value into Big. NaN can not be converted.
+func NewBig(a Value) (Big, error) {
+	if a.IsNaN() {
+		return Big{}, ErrNaN
+	}
+	return Big{rat: big.NewRat(a.Num, a.Div)}, nil
+}
+
+// NewBigFromRat creates Big from copy of the rational number.
+func NewBigFromRat(r *big.Rat) Big {
+	return Big{rat: new(big.Rat).Set(r)}
+}
@@ -0,0 +1,65 @@
+package natural
+
+import (
+	assert "github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	a := Value{Num: 1, Div: 4294967291}
+	b := Value{Num: 1, Div: 4294967279}
+
+	_, err := a.AddChecked(b)
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	res, err := Value{Num: 1, Div: 6}.AddChecked(Value{Num: 1, Div: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, Value{Num: 1, Div: 2}, res)
+
+	res, err = Value{Num: math.MaxInt64, Div: 3}.MultipleChecked(Value{Num: 3, Div: math.MaxInt64})
+	assert.NoError(t, err)
+	assert.Equal(t, Value{Num: 1, Div: 1}, res)
+
+	_, err = Value{Num: math.MaxInt64, Div: 1}.MultipleChecked(Value{Num: 2, Div: 1})
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	res, err = Value{Num: 5, Div: 3}.SubtractChecked(Value{Num: 2, Div: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, Value{Num: 19, Div: 15}, res)
+
+	_, err = Value{Num: 1, Div: 2}.DivideChecked(Value{Num: 0, Div: 1})
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = Value{Num: 1, Div: 2}.AddChecked(NaN)
+	assert.ErrorIs(t, err, ErrNaN)
+}
+
+func TestBig(t *testing.T) {
+	a, err := NewBig(Value{Num: 1, Div: 4294967291})
+	assert.NoError(t, err)
+	b, err := NewBig(Value{Num: 1, Div: 4294967279})
+	assert.NoError(t, err)
+
+	sum := a.Add(b)
+	assert.Equal(t, "8589934570/18446743979220271189", sum.String())
+	_, err = sum.Value()
+	assert.ErrorIs(t, err, ErrOverflow)
+
+	product := sum.Multiple(sum)
+
+	res, err := product.Divide(sum)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Cmp(sum))
+
+	v, err := res.Subtract(b).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Value{Num: 1, Div: 4294967291}, v)
+
+	var zero Big
+	_, err = sum.Divide(zero)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = NewBig(NaN)
+	assert.ErrorIs(t, err, ErrNaN)
+}
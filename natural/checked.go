@@ -0,0 +1,102 @@
+package natural
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrOverflow is returned, when result of operation does not fit into int64.
+var ErrOverflow = errors.New("natural: integer overflow")
+
+// ErrDivisionByZero is returned on division by zero fraction.
+var ErrDivisionByZero = errors.New("natural: division by zero")
+
+// ErrNaN is returned, when operand of operation is invalid fraction.
+var ErrNaN = errors.New("natural: invalid fraction")
+
+// AddChecked adds natural fractions and reports overflow. Result is canonical.
+func (a Value) AddChecked(b Value) (Value, error) {
+	if a.IsNaN() || b.IsNaN() {
+		return NaN, ErrNaN
+	}
+
+	a = a.Simplify()
+	b = b.Simplify()
+	g := gcd(a.Div, b.Div)
+	div, ok := mul64(a.Div/g, b.Div)
+	if !ok {
+		return NaN, ErrOverflow
+	}
+	x, ok1 := mul64(a.Num, b.Div/g)
+	y, ok2 := mul64(b.Num, a.Div/g)
+	if !ok1 || !ok2 {
+		return NaN, ErrOverflow
+	}
+	num, ok := add64(x, y)
+	if !ok {
+		return NaN, ErrOverflow
+	}
+	return Value{Num: num, Div: div}.Simplify(), nil
+}
+
+// SubtractChecked subtracts natural fractions and reports overflow. Result is canonical.
+func (a Value) SubtractChecked(b Value) (Value, error) {
+	if b.IsNaN() {
+		return NaN, ErrNaN
+	}
+	b = b.Simplify()
+	if b.Num == math.MinInt64 {
+		return NaN, ErrOverflow
+	}
+	return a.AddChecked(b.Negate())
+}
+
+// MultipleChecked multiplies natural fractions and reports overflow. Result is canonical.
+func (a Value) MultipleChecked(b Value) (Value, error) {
+	if a.IsNaN() || b.IsNaN() {
+		return NaN, ErrNaN
+	}
+
+	a = a.Simplify()
+	b = b.Simplify()
+	g1 := coalesce(gcd(a.Num, b.Div), 1)
+	g2 := coalesce(gcd(b.Num, a.Div), 1)
+	num, ok1 := mul64(a.Num/g1, b.Num/g2)
+	div, ok2 := mul64(a.Div/g2, b.Div/g1)
+	if !ok1 || !ok2 {
+		return NaN, ErrOverflow
+	}
+	return Value{Num: num, Div: div}.Simplify(), nil
+}
+
+// DivideChecked divides natural fractions and reports overflow or division by zero.
+// Result is canonical.
+func (a Value) DivideChecked(b Value) (Value, error) {
+	if a.IsNaN() || b.IsNaN() {
+		return NaN, ErrNaN
+	}
+	b = b.Simplify()
+	if b.Num == 0 {
+		return NaN, ErrDivisionByZero
+	}
+	return a.MultipleChecked(Value{Num: b.Div, Div: b.Num})
+}
+
+func mul64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || c/b != a {
+		return 0, false
+	}
+	return c, true
+}
+
+func add64(a, b int64) (int64, bool) {
+	c := a + b
+	if (c > a) != (b > 0) {
+		return 0, false
+	}
+	return c, true
+}
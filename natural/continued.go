@@ -0,0 +1,112 @@
+package natural
+
+import (
+	"math"
+)
+
+// maxConvertible is upper bound of floats, that can be converted into natural fractions.
+const maxConvertible = float64(1 << 63)
+
+// Convergents returns sequence of convergents of the continued fraction expansion.
+// The last convergent is equal to the value. NaN has no convergents.
+// Example: 13/8 -> [1/1, 2/1, 3/2, 5/3, 13/8]
+func (a Value) Convergents() []Value {
+	a = a.Simplify()
+	if a.IsNaN() {
+		return nil
+	}
+
+	var res []Value
+	num, div := a.Num, a.Div
+	h, k, h1, k1 := int64(1), int64(0), int64(0), int64(1)
+	for div != 0 {
+		term := floorDiv(num, div)
+		num, div = div, num-term*div
+		h, h1 = term*h+h1, h
+		k, k1 = term*k+k1, k
+		res = append(res, Value{Num: h, Div: k})
+	}
+	return res
+}
+
+// convergents is iterator over convergents of the non-negative float.
+// Iteration stops, when convergent is equal to the float or next convergent overflows int64.
+type convergents struct {
+	src  float64
+	rest float64
+	term int64
+	done bool
+	// Current and two previous convergents
+	h, k   int64
+	h1, k1 int64
+	h2, k2 int64
+}
+
+// Next calculates next convergent.
+func (that *convergents) Next() bool {
+	if that.done {
+		return false
+	}
+
+	a := math.Floor(that.rest)
+	if a >= maxConvertible {
+		that.done = true
+		return false
+	}
+	term := int64(a)
+	x, ok1 := mul64(term, that.h)
+	y, ok2 := mul64(term, that.k)
+	h, ok3 := add64(x, that.h1)
+	k, ok4 := add64(y, that.k1)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		that.done = true
+		return false
+	}
+
+	that.h2, that.k2 = that.h1, that.k1
+	that.h1, that.k1 = that.h, that.k
+	that.h, that.k = h, k
+	that.term = term
+
+	frac := that.rest - a
+	if frac == 0 || float64(h)/float64(k) == that.src {
+		that.done = true
+	} else {
+		that.rest = 1 / frac
+	}
+	return true
+}
+
+// Value returns current convergent.
+func (that *convergents) Value() Value {
+	return Value{Num: that.h, Div: that.k}
+}
+
+// semiconvergent returns intermediate fraction between two previous convergents.
+// semiconvergent(term) is equal to the current convergent.
+func (that *convergents) semiconvergent(m int64) Value {
+	return Value{
+		Num: that.h2 + m*that.h1,
+		Div: that.k2 + m*that.k1,
+	}
+}
+
+func newConvergents(x float64) *convergents {
+	return &convergents{
+		src:  x,
+		rest: x,
+		h:    1,
+		k:    0,
+		h1:   0,
+		k1:   1,
+	}
+}
+
+// floorDiv returns largest integer not greater than a/b.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,157 @@
+package natural
+
+import (
+	assert "github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestNewFromFloat(t *testing.T) {
+	type Test struct {
+		src     float64
+		epsilon float64
+		dst     Value
+	}
+
+	tests := map[string]Test{
+		"zero": {
+			src:     0,
+			epsilon: 1e-6,
+			dst:     Value{Num: 0, Div: 1},
+		},
+		"integer": {
+			src:     3,
+			epsilon: 1e-6,
+			dst:     Value{Num: 3, Div: 1},
+		},
+		"exact": {
+			src:     0.75,
+			epsilon: 0,
+			dst:     Value{Num: 3, Div: 4},
+		},
+		"more than half": {
+			src:     16.0 / 9,
+			epsilon: 1e-9,
+			dst:     Value{Num: 16, Div: 9},
+		},
+		"negative": {
+			src:     -1.5,
+			epsilon: 1e-9,
+			dst:     Value{Num: -3, Div: 2},
+		},
+		"pi rough": {
+			src:     math.Pi,
+			epsilon: 1e-2,
+			dst:     Value{Num: 22, Div: 7},
+		},
+		"pi fine": {
+			src:     math.Pi,
+			epsilon: 1e-6,
+			dst:     Value{Num: 355, Div: 113},
+		},
+		"semiconvergent": {
+			src:     0.3,
+			epsilon: 0.02,
+			dst:     Value{Num: 2, Div: 7},
+		},
+		"closest numerator": {
+			src:     2.7,
+			epsilon: 1,
+			dst:     Value{Num: 3, Div: 1},
+		},
+		"closest numerator below one": {
+			src:     0.7,
+			epsilon: 1,
+			dst:     Value{Num: 1, Div: 1},
+		},
+		"decimal": {
+			src:     0.1,
+			epsilon: 0,
+			dst:     Value{Num: 1, Div: 10},
+		},
+		"NaN": {
+			src: math.NaN(),
+			dst: NaN,
+		},
+		"too large": {
+			src: 1e19,
+			dst: NaN,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := NewFromFloat(test.src, test.epsilon)
+			assert.Equal(t, test.dst, actual)
+		})
+	}
+}
+
+func TestNewFromFloatMaxDenominator(t *testing.T) {
+	type Test struct {
+		src    float64
+		maxDiv int64
+		dst    Value
+	}
+
+	tests := map[string]Test{
+		"pi by 10": {
+			src:    math.Pi,
+			maxDiv: 10,
+			dst:    Value{Num: 22, Div: 7},
+		},
+		"pi by 100": {
+			src:    math.Pi,
+			maxDiv: 100,
+			dst:    Value{Num: 311, Div: 99},
+		},
+		"pi by 1000": {
+			src:    math.Pi,
+			maxDiv: 1000,
+			dst:    Value{Num: 355, Div: 113},
+		},
+		"exact": {
+			src:    0.125,
+			maxDiv: 1000,
+			dst:    Value{Num: 1, Div: 8},
+		},
+		"integer only": {
+			src:    2.7,
+			maxDiv: 1,
+			dst:    Value{Num: 3, Div: 1},
+		},
+		"negative": {
+			src:    -0.333,
+			maxDiv: 10,
+			dst:    Value{Num: -1, Div: 3},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := NewFromFloatMaxDenominator(test.src, test.maxDiv)
+			assert.Equal(t, test.dst, actual)
+		})
+	}
+}
+
+func TestConvergents(t *testing.T) {
+	assert.Equal(
+		t,
+		[]Value{{1, 1}, {2, 1}, {3, 2}, {5, 3}, {13, 8}},
+		Value{Num: 13, Div: 8}.Convergents(),
+	)
+	assert.Equal(
+		t,
+		[]Value{{-1, 1}, {-2, 3}},
+		Value{Num: -2, Div: 3}.Convergents(),
+	)
+	assert.Equal(t, []Value{{5, 1}}, Value{Num: 5, Div: 1}.Convergents())
+	assert.Nil(t, NaN.Convergents())
+}
+
+func BenchmarkNewFromFloat(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewFromFloat(math.Pi, 1e-9)
+	}
+}
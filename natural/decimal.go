@@ -0,0 +1,335 @@
+package natural
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale limits scale of decimals and exponent of parsed literals.
+const MaxDecimalScale = 1 << 12
+
+// Decimal is exact fixed-point decimal number mantissa*10^-scale.
+// Decimal is immutable: all operations return new value. Zero value is 0.
+// Scale is kept by operations (1.50 + 1 is 2.50), so decimal preserves its precision.
+type Decimal struct {
+	mantissa *big.Int
+	scale    int
+}
+
+// Mantissa returns copy of the unscaled value.
+func (a Decimal) Mantissa() *big.Int {
+	return new(big.Int).Set(a.get())
+}
+
+// Scale returns count of digits after the decimal point.
+func (a Decimal) Scale() int {
+	return a.scale
+}
+
+// Sign returns -1, 0 or 1.
+func (a Decimal) Sign() int {
+	return a.get().Sign()
+}
+
+// IsZero checks if decimal is zero.
+func (a Decimal) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// Cmp compares decimals and returns -1, 0 or 1. Scale is ignored (1.5 is equal to 1.50).
+func (a Decimal) Cmp(b Decimal) int {
+	x, y := align(a, b)
+	return x.Cmp(y)
+}
+
+// IsEqual checks if a is equal to b regardless of scale.
+func (a Decimal) IsEqual(b Decimal) bool {
+	return a.Cmp(b) == 0
+}
+
+// String returns literal representation with exactly Scale digits after the point.
+func (a Decimal) String() string {
+	var sb strings.Builder
+	m := a.get()
+	if m.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	text := new(big.Int).Abs(m).String()
+	if len(text) <= a.scale {
+		text = strings.Repeat("0", a.scale-len(text)+1) + text
+	}
+	sb.WriteString(text[:len(text)-a.scale])
+	if a.scale > 0 {
+		sb.WriteByte('.')
+		sb.WriteString(text[len(text)-a.scale:])
+	}
+	return sb.String()
+}
+
+// Float representation of decimal (can lose precision).
+func (a Decimal) Float() float64 {
+	f, _ := new(big.Rat).SetFrac(a.get(), pow10(a.scale)).Float64()
+	return f
+}
+
+// Negate returns negative decimal
+func (a Decimal) Negate() Decimal {
+	return Decimal{mantissa: new(big.Int).Neg(a.get()), scale: a.scale}
+}
+
+// Abs returns absolute value of decimal
+func (a Decimal) Abs() Decimal {
+	return Decimal{mantissa: new(big.Int).Abs(a.get()), scale: a.scale}
+}
+
+// Add decimals. Scale of result is the largest scale of operands.
+func (a Decimal) Add(b Decimal) Decimal {
+	x, y := align(a, b)
+	return Decimal{mantissa: x.Add(x, y), scale: max(a.scale, b.scale)}
+}
+
+// Subtract decimals. Scale of result is the largest scale of operands.
+func (a Decimal) Subtract(b Decimal) Decimal {
+	x, y := align(a, b)
+	return Decimal{mantissa: x.Sub(x, y), scale: max(a.scale, b.scale)}
+}
+
+// Multiple decimals exactly. Scale of result is the sum of scales of operands.
+// Use Round for reduce scale of the result.
+func (a Decimal) Multiple(b Decimal) Decimal {
+	return Decimal{
+		mantissa: new(big.Int).Mul(a.get(), b.get()),
+		scale:    a.scale + b.scale,
+	}.limit()
+}
+
+// Divide decimals and round result to the scale by the mode.
+func (a Decimal) Divide(b Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if b.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrOverflow
+	}
+
+	// a/b = (a.m * 10^(scale+b.scale-a.scale) / b.m) * 10^-scale
+	num := a.Mantissa()
+	div := b.Mantissa()
+	if shift := scale + b.scale - a.scale; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		div.Mul(div, pow10(-shift))
+	}
+	if div.Sign() < 0 {
+		num.Neg(num)
+		div.Neg(div)
+	}
+	return Decimal{mantissa: roundQuotient(num, div, mode), scale: scale}, nil
+}
+
+// Round changes scale of decimal. Extra digits are rounded by the mode.
+// Scale is clamped to [0, MaxDecimalScale].
+func (a Decimal) Round(scale int, mode RoundingMode) Decimal {
+	scale = min(max(scale, 0), MaxDecimalScale)
+	if scale >= a.scale {
+		return a.rescale(scale)
+	}
+	return Decimal{
+		mantissa: roundQuotient(a.get(), pow10(a.scale-scale), mode),
+		scale:    scale,
+	}
+}
+
+// Natural converts decimal into natural fraction or returns ErrOverflow.
+func (a Decimal) Natural() (Value, error) {
+	return NewBigFromRat(new(big.Rat).SetFrac(a.get(), pow10(a.scale))).Value()
+}
+
+func (a Decimal) get() *big.Int {
+	if a.mantissa == nil {
+		return new(big.Int)
+	}
+	return a.mantissa
+}
+
+func (a Decimal) rescale(scale int) Decimal {
+	if scale == a.scale {
+		return a
+	}
+	return Decimal{
+		mantissa: new(big.Int).Mul(a.get(), pow10(scale-a.scale)),
+		scale:    scale,
+	}
+}
+
+// limit rounds decimal, which scale exceeds MaxDecimalScale.
+func (a Decimal) limit() Decimal {
+	if a.scale > MaxDecimalScale {
+		return a.Round(MaxDecimalScale, RoundHalfEven)
+	}
+	return a
+}
+
+// align returns mantissas of decimals with the same scale.
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	scale := max(a.scale, b.scale)
+	return a.rescale(scale).Mantissa(), b.rescale(scale).Mantissa()
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// MarshalJSON encodes decimal as JSON number.
+func (a Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON decodes decimal from JSON number or string.
+func (a *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return a.UnmarshalText(data)
+}
+
+// MarshalText encodes decimal as plain decimal literal.
+func (a Decimal) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes decimal with ParseDecimal.
+func (a *Decimal) UnmarshalText(text []byte) error {
+	res, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*a = res
+	return nil
+}
+
+// Scan implements sql.Scanner. NULL is scanned as zero.
+func (a *Decimal) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Decimal{}
+		return nil
+	case int64:
+		*a = NewDecimal(v, 0)
+		return nil
+	case float64:
+		return a.UnmarshalText([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		return a.UnmarshalText(v)
+	default:
+		return fmt.Errorf("natural: unsupported scan type %T into Decimal", src)
+	}
+}
+
+// Value implements driver.Valuer. Decimal is stored as string.
+func (a Decimal) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+// NewDecimal is constructor for creating decimal mantissa*10^-scale.
+// Scale is clamped to [-MaxDecimalScale, MaxDecimalScale] (positive scale is rounded).
+func NewDecimal(mantissa int64, scale int) Decimal {
+	res := Decimal{mantissa: big.NewInt(mantissa), scale: max(scale, -MaxDecimalScale)}
+	if res.scale < 0 {
+		return res.rescale(0)
+	}
+	return res.limit()
+}
+
+// NewDecimalFromNatural converts natural fraction into decimal with scale.
+// Extra digits are rounded by the mode.
+func NewDecimalFromNatural(value Value, scale int, mode RoundingMode) (Decimal, error) {
+	value = value.Simplify()
+	if value.IsNaN() {
+		return Decimal{}, ErrNaN
+	}
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, ErrOverflow
+	}
+	num := new(big.Int).Mul(big.NewInt(value.Num), pow10(scale))
+	return Decimal{
+		mantissa: roundQuotient(num, big.NewInt(value.Div), mode),
+		scale:    scale,
+	}, nil
+}
+
+// ParseDecimal parses decimal literal exactly ("12", "-0.50", "1.5e3", "+2.25E-2").
+// Scale of the result is count of significant digits after the point ("0.50" has scale 2).
+func ParseDecimal(s string) (Decimal, error) {
+	src := s
+	s = strings.TrimSpace(s)
+
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exp > MaxDecimalScale || exp < -MaxDecimalScale {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, src)
+		}
+		s = s[:i]
+	}
+
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrSyntax, src)
+	}
+
+	mantissa, _ := new(big.Int).SetString(whole+frac, 10)
+	if neg {
+		mantissa.Neg(mantissa)
+	}
+
+	scale := len(frac) - int(exp)
+	if scale > MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrOverflow, src)
+	}
+	res := Decimal{mantissa: mantissa, scale: scale}
+	if scale < 0 {
+		return res.rescale(0), nil
+	}
+	return res, nil
+}
+
+// MustParseDecimal is like ParseDecimal, but panics on error.
+func MustParseDecimal(s string) Decimal {
+	res, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
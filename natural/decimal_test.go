@@ -0,0 +1,136 @@
+package natural
+
+import (
+	"encoding/json"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	type Test struct {
+		src   string
+		dst   string
+		scale int
+		err   error
+	}
+
+	tests := map[string]Test{
+		"integer":          {src: "12", dst: "12", scale: 0},
+		"trailing zeros":   {src: "-0.50", dst: "-0.50", scale: 2},
+		"plus sign":        {src: "+2.25", dst: "2.25", scale: 2},
+		"leading point":    {src: ".5", dst: "0.5", scale: 1},
+		"exponent":         {src: "1.5e3", dst: "1500", scale: 0},
+		"negative exp":     {src: "2.25E-2", dst: "0.0225", scale: 4},
+		"many digits":      {src: "123456789012345678901234567890.123456789", dst: "123456789012345678901234567890.123456789", scale: 9},
+		"empty":            {src: "", err: ErrSyntax},
+		"point":            {src: ".", err: ErrSyntax},
+		"letters":          {src: "1.2x", err: ErrSyntax},
+		"fraction":         {src: "1/2", err: ErrSyntax},
+		"huge exponent":    {src: "1e999999", err: ErrSyntax},
+		"double sign":      {src: "--1", err: ErrSyntax},
+		"exponent without": {src: "1e", err: ErrSyntax},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := ParseDecimal(test.src)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.dst, actual.String())
+			assert.Equal(t, test.scale, actual.Scale())
+		})
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := MustParseDecimal("10.50")
+	b := MustParseDecimal("0.125")
+
+	assert.Equal(t, "10.625", a.Add(b).String())
+	assert.Equal(t, "10.375", a.Subtract(b).String())
+	assert.Equal(t, "1.31250", a.Multiple(b).String())
+	assert.Equal(t, "-10.50", a.Negate().String())
+	assert.Equal(t, "10.50", a.Negate().Abs().String())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.True(t, MustParseDecimal("1.5").IsEqual(MustParseDecimal("1.500")))
+	assert.Equal(t, "1200", NewDecimal(12, -2).String())
+	assert.Equal(t, NewDecimal(1, -MaxDecimalScale), NewDecimal(1, -2_000_000))
+
+	q, err := MustParseDecimal("10").Divide(MustParseDecimal("3"), 2, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "3.33", q.String())
+
+	q, err = MustParseDecimal("-2").Divide(MustParseDecimal("0.3"), 3, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "-6.667", q.String())
+
+	q, err = MustParseDecimal("1.00").Divide(MustParseDecimal("-8"), 2, RoundHalfEven)
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.12", q.String())
+
+	_, err = a.Divide(Decimal{}, 2, RoundHalfUp)
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	assert.Equal(t, "2.68", MustParseDecimal("2.675").Round(2, RoundHalfUp).String())
+	assert.Equal(t, "2.67", MustParseDecimal("2.675").Round(2, RoundTowardZero).String())
+	assert.Equal(t, "-3", MustParseDecimal("-2.5").Round(0, RoundHalfUp).String())
+	assert.Equal(t, "2.5000", MustParseDecimal("2.5").Round(4, RoundHalfUp).String())
+	assert.Equal(t, MaxDecimalScale, MustParseDecimal("2.5").Round(1<<30, RoundHalfUp).Scale())
+
+	var zero Decimal
+	assert.Equal(t, "0", zero.String())
+	assert.True(t, zero.Add(a).IsEqual(a))
+}
+
+func TestDecimalNatural(t *testing.T) {
+	v, err := MustParseDecimal("-1.250").Natural()
+	assert.NoError(t, err)
+	assert.Equal(t, Value{Num: -5, Div: 4}, v)
+
+	d, err := NewDecimalFromNatural(Value{Num: 2, Div: 3}, 4, RoundHalfUp)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.6667", d.String())
+
+	_, err = NewDecimalFromNatural(NaN, 2, RoundHalfUp)
+	assert.ErrorIs(t, err, ErrNaN)
+
+	_, err = MustParseDecimal("1e30").Natural()
+	assert.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestDecimalEncoding(t *testing.T) {
+	type Invoice struct {
+		Total Decimal `json:"total"`
+	}
+
+	data, err := json.Marshal(Invoice{Total: MustParseDecimal("19.90")})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"total":19.90}`, string(data))
+
+	var inv Invoice
+	assert.NoError(t, json.Unmarshal([]byte(`{"total":12345678901234567890.01}`), &inv))
+	assert.Equal(t, "12345678901234567890.01", inv.Total.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"total":"0.10"}`), &inv))
+	assert.Equal(t, "0.10", inv.Total.String())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"total":true}`), &inv))
+
+	val, err := MustParseDecimal("0.10").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.10", val)
+
+	var d Decimal
+	assert.NoError(t, d.Scan([]byte("42.00")))
+	assert.Equal(t, "42.00", d.String())
+	assert.NoError(t, d.Scan(int64(7)))
+	assert.Equal(t, "7", d.String())
+	assert.NoError(t, d.Scan(0.1))
+	assert.Equal(t, "0.1", d.String())
+	assert.NoError(t, d.Scan(nil))
+	assert.True(t, d.IsZero())
+	assert.Error(t, d.Scan(true))
+}
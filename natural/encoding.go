@@ -0,0 +1,207 @@
+package natural
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned, when string is not valid natural fraction.
+var ErrSyntax = errors.New("natural: invalid syntax")
+
+// Parse converts string into canonical natural fraction.
+// Supported forms are integers ("3"), fractions ("3/4"),
+// mixed numbers ("-1 1/2") and exact decimals ("0.75", "1.5e-3").
+// String "NaN" is parsed as NaN.
+func Parse(s string) (Value, error) {
+	s = strings.TrimSpace(s)
+	if s == "NaN" {
+		return NaN, nil
+	}
+
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+		if strings.Contains(s, "/") {
+			return parseFraction(s)
+		}
+		return parseDecimal(s)
+	case 2:
+		return parseMixed(fields[0], fields[1])
+	default:
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+}
+
+// MustParse is like Parse, but panics on error.
+func MustParse(s string) Value {
+	res, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func parseFraction(s string) (Value, error) {
+	num, div, ok := strings.Cut(s, "/")
+	if !ok {
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	n, err := parseInt(num)
+	if err != nil {
+		return NaN, err
+	}
+	d, err := parseInt(div)
+	if err != nil {
+		return NaN, err
+	}
+	return New(n, d)
+}
+
+func parseDecimal(s string) (Value, error) {
+	if s == "" || strings.ContainsAny(s, "xXbBoOpP_") {
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	return NewBigFromRat(r).Value()
+}
+
+func parseMixed(whole, fraction string) (Value, error) {
+	w, err := parseInt(whole)
+	if err != nil {
+		return NaN, err
+	}
+	// Sign belongs to the whole part, fraction part must be unsigned
+	if strings.ContainsAny(fraction, "+-") {
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, whole+" "+fraction)
+	}
+	f, err := parseFraction(fraction)
+	if err != nil {
+		return NaN, err
+	}
+	if f.Num >= f.Div {
+		return NaN, fmt.Errorf("%w: %q", ErrSyntax, whole+" "+fraction)
+	}
+	if strings.HasPrefix(whole, "-") {
+		f = f.Negate()
+	}
+	return Value{Num: w, Div: 1}.AddChecked(f)
+}
+
+func parseInt(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, ErrOverflow
+		}
+		return 0, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	return n, nil
+}
+
+// jsonValue is object form of natural fraction in JSON.
+type jsonValue struct {
+	Num int64 `json:"num"`
+	Div int64 `json:"div"`
+}
+
+// MarshalJSON encodes natural fraction as string "num/div".
+func (a Value) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Simplify().String())
+}
+
+// UnmarshalJSON decodes natural fraction from string ("3/4", "-1 1/2", "0.75"),
+// number (0.75) or object ({"num":3,"div":4}) form.
+func (a *Value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty input", ErrSyntax)
+	}
+
+	switch data[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return a.UnmarshalText([]byte(s))
+	case '{':
+		var v jsonValue
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		res, err := New(v.Num, v.Div)
+		if err != nil {
+			return err
+		}
+		*a = res
+		return nil
+	case 'n':
+		if string(data) == "null" {
+			return nil
+		}
+	}
+
+	res, err := parseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*a = res
+	return nil
+}
+
+// MarshalText encodes natural fraction as "num/div".
+func (a Value) MarshalText() ([]byte, error) {
+	return []byte(a.Simplify().String()), nil
+}
+
+// UnmarshalText decodes natural fraction with Parse.
+func (a *Value) UnmarshalText(text []byte) error {
+	res, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*a = res
+	return nil
+}
+
+// Scan implements sql.Scanner. NULL is scanned as NaN.
+func (a *Value) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*a = NaN
+		return nil
+	case int64:
+		*a = Value{Num: v, Div: 1}
+		return nil
+	case float64:
+		res, err := parseDecimal(strconv.FormatFloat(v, 'g', -1, 64))
+		if err != nil {
+			return err
+		}
+		*a = res
+		return nil
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		return a.UnmarshalText(v)
+	default:
+		return fmt.Errorf("natural: unsupported scan type %T", src)
+	}
+}
+
+// Value implements driver.Valuer. Fraction is stored as string "num/div", NaN is stored as NULL.
+func (a Value) Value() (driver.Value, error) {
+	if a.IsNaN() {
+		return nil, nil
+	}
+	return a.Simplify().String(), nil
+}
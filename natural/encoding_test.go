@@ -0,0 +1,181 @@
+package natural
+
+import (
+	"encoding/json"
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type Test struct {
+		src string
+		dst Value
+		err error
+	}
+
+	tests := map[string]Test{
+		"integer": {
+			src: "3",
+			dst: Value{Num: 3, Div: 1},
+		},
+		"negative integer": {
+			src: "-7",
+			dst: Value{Num: -7, Div: 1},
+		},
+		"fraction": {
+			src: "6/8",
+			dst: Value{Num: 3, Div: 4},
+		},
+		"fraction with negative denominator": {
+			src: "3/-4",
+			dst: Value{Num: -3, Div: 4},
+		},
+		"mixed number": {
+			src: "1 1/2",
+			dst: Value{Num: 3, Div: 2},
+		},
+		"negative mixed number": {
+			src: "-1 1/2",
+			dst: Value{Num: -3, Div: 2},
+		},
+		"decimal": {
+			src: "0.75",
+			dst: Value{Num: 3, Div: 4},
+		},
+		"negative decimal with spaces": {
+			src: " -1.5 ",
+			dst: Value{Num: -3, Div: 2},
+		},
+		"decimal with exponent": {
+			src: "1.5e-3",
+			dst: Value{Num: 3, Div: 2000},
+		},
+		"NaN": {
+			src: "NaN",
+			dst: NaN,
+		},
+		"zero denominator": {
+			src: "1/0",
+			err: ErrDivisionByZero,
+		},
+		"improper mixed number": {
+			src: "1 3/2",
+			err: ErrSyntax,
+		},
+		"signed denominator of mixed number": {
+			src: "1 3/-4",
+			err: ErrSyntax,
+		},
+		"signed numerator of mixed number": {
+			src: "1 -3/4",
+			err: ErrSyntax,
+		},
+		"garbage": {
+			src: "abc",
+			err: ErrSyntax,
+		},
+		"hex": {
+			src: "0x10",
+			err: ErrSyntax,
+		},
+		"empty": {
+			src: "",
+			err: ErrSyntax,
+		},
+		"overflow": {
+			src: "99999999999999999999/2",
+			err: ErrOverflow,
+		},
+		"huge exponent": {
+			src: "1e999999",
+			err: ErrOverflow,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, err := Parse(test.src)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				if test.err == ErrOverflow {
+					assert.True(t, actual.IsNaN())
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.dst, actual)
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type Record struct {
+		Ratio Value `json:"ratio"`
+	}
+
+	data, err := json.Marshal(Record{Ratio: Value{Num: 6, Div: -8}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ratio":"-3/4"}`, string(data))
+
+	tests := map[string]string{
+		"string":  `{"ratio":"-3/4"}`,
+		"mixed":   `{"ratio":"-0 3/4"}`,
+		"number":  `{"ratio":-0.75}`,
+		"object":  `{"ratio":{"num":3,"div":-4}}`,
+		"decimal": `{"ratio":"-0.75"}`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			var rec Record
+			assert.NoError(t, json.Unmarshal([]byte(src), &rec))
+			assert.Equal(t, Value{Num: -3, Div: 4}, rec.Ratio)
+		})
+	}
+
+	var rec Record
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"ratio":{"num":1,"div":0}}`), &rec), ErrDivisionByZero)
+	assert.Error(t, json.Unmarshal([]byte(`{"ratio":true}`), &rec))
+}
+
+func TestText(t *testing.T) {
+	text, err := Value{Num: 2, Div: 4}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "1/2", string(text))
+
+	var v Value
+	assert.NoError(t, v.UnmarshalText([]byte("1 1/4")))
+	assert.Equal(t, Value{Num: 5, Div: 4}, v)
+}
+
+func TestSQL(t *testing.T) {
+	val, err := Value{Num: 3, Div: 4}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "3/4", val)
+
+	val, err = NaN.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+
+	tests := map[string]struct {
+		src interface{}
+		dst Value
+	}{
+		"string":  {src: "3/4", dst: Value{Num: 3, Div: 4}},
+		"bytes":   {src: []byte("0.75"), dst: Value{Num: 3, Div: 4}},
+		"int64":   {src: int64(5), dst: Value{Num: 5, Div: 1}},
+		"float64": {src: 0.1, dst: Value{Num: 1, Div: 10}},
+		"null":    {src: nil, dst: NaN},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var v Value
+			assert.NoError(t, v.Scan(test.src))
+			assert.Equal(t, test.dst, v)
+		})
+	}
+
+	var v Value
+	assert.Error(t, v.Scan(true))
+}
@@ -0,0 +1,117 @@
+package natural
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// MaxRepeatingDigits limits length of fractional part in FormatRepeating.
+const MaxRepeatingDigits = 1000
+
+// FormatDecimal formats natural fraction as decimal with exactly digits digits
+// after the point, rounded by the mode.
+// Example: 2/3 with 2 digits and RoundHalfUp is "0.67".
+func (a Value) FormatDecimal(digits int, mode RoundingMode) string {
+	a = a.Simplify()
+	if a.IsNaN() {
+		return "NaN"
+	}
+	if digits < 0 {
+		digits = 0
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	num := new(big.Int).Mul(big.NewInt(a.Num), scale)
+	res := roundQuotient(num, big.NewInt(a.Div), mode)
+
+	var sb strings.Builder
+	if res.Sign() < 0 {
+		sb.WriteByte('-')
+	}
+	text := new(big.Int).Abs(res).String()
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	sb.WriteString(text[:len(text)-digits])
+	if digits > 0 {
+		sb.WriteByte('.')
+		sb.WriteString(text[len(text)-digits:])
+	}
+	return sb.String()
+}
+
+// FormatMixed formats natural fraction as mixed number.
+// Example: 3/2 is "1 1/2", -3/2 is "-1 1/2", 1/2 is "1/2", 4/1 is "4".
+func (a Value) FormatMixed() string {
+	a = a.Simplify()
+	if a.IsNaN() {
+		return "NaN"
+	}
+
+	whole := a.Truncate().Num
+	rest := a.Fraction().Abs()
+	switch {
+	case rest.Num == 0:
+		return strconv.FormatInt(whole, 10)
+	case whole == 0:
+		return a.String()
+	default:
+		return strconv.FormatInt(whole, 10) + " " + rest.String()
+	}
+}
+
+// FormatRepeating formats natural fraction as exact decimal,
+// repeating part of the fraction is enclosed in parentheses.
+// Fractional part longer than MaxRepeatingDigits is truncated and terminated with "...".
+// Example: 1/3 is "0.(3)", 1/6 is "0.1(6)", 1/4 is "0.25".
+func (a Value) FormatRepeating() string {
+	a = a.Simplify()
+	if a.IsNaN() {
+		return "NaN"
+	}
+
+	var sb strings.Builder
+	if a.Num < 0 {
+		sb.WriteByte('-')
+	}
+	// Conversion to uint64 keeps absolute value of math.MinInt64
+	num := uint64(abs(a.Num))
+	div := uint64(a.Div)
+	sb.WriteString(strconv.FormatUint(num/div, 10))
+
+	rem := num % div
+	if rem == 0 {
+		return sb.String()
+	}
+
+	// Long division until remainder repeats
+	var digits []byte
+	positions := make(map[uint64]int)
+	for rem != 0 {
+		if pos, ok := positions[rem]; ok {
+			sb.WriteByte('.')
+			sb.Write(digits[:pos])
+			sb.WriteByte('(')
+			sb.Write(digits[pos:])
+			sb.WriteByte(')')
+			return sb.String()
+		}
+		if len(digits) == MaxRepeatingDigits {
+			sb.WriteByte('.')
+			sb.Write(digits)
+			sb.WriteString("...")
+			return sb.String()
+		}
+		positions[rem] = len(digits)
+		hi, lo := bits.Mul64(rem, 10)
+		var digit uint64
+		digit, rem = bits.Div64(hi, lo, div)
+		digits = append(digits, byte('0'+digit))
+	}
+
+	sb.WriteByte('.')
+	sb.Write(digits)
+	return sb.String()
+}
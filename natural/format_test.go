@@ -0,0 +1,72 @@
+package natural
+
+import (
+	assert "github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func TestFormatDecimal(t *testing.T) {
+	type Test struct {
+		src    Value
+		digits int
+		mode   RoundingMode
+		dst    string
+	}
+
+	tests := map[string]Test{
+		"2/3":              {src: Value{2, 3}, digits: 2, mode: RoundHalfUp, dst: "0.67"},
+		"2/3 toward zero":  {src: Value{2, 3}, digits: 2, mode: RoundTowardZero, dst: "0.66"},
+		"-2/3":             {src: Value{-2, 3}, digits: 3, mode: RoundHalfUp, dst: "-0.667"},
+		"1/8 half-even":    {src: Value{1, 8}, digits: 2, mode: RoundHalfEven, dst: "0.12"},
+		"1/8 half-up":      {src: Value{1, 8}, digits: 2, mode: RoundHalfUp, dst: "0.13"},
+		"integer":          {src: Value{7, 1}, digits: 2, mode: RoundHalfUp, dst: "7.00"},
+		"no digits":        {src: Value{5, 2}, digits: 0, mode: RoundHalfUp, dst: "3"},
+		"small":            {src: Value{1, 1000}, digits: 4, mode: RoundHalfUp, dst: "0.0010"},
+		"negative to zero": {src: Value{-1, 1000}, digits: 2, mode: RoundHalfUp, dst: "0.00"},
+		"NaN":              {src: NaN, digits: 2, mode: RoundHalfUp, dst: "NaN"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.dst, test.src.FormatDecimal(test.digits, test.mode))
+		})
+	}
+}
+
+func TestFormatMixed(t *testing.T) {
+	tests := map[Value]string{
+		{3, 2}:  "1 1/2",
+		{-3, 2}: "-1 1/2",
+		{1, 2}:  "1/2",
+		{-1, 2}: "-1/2",
+		{8, 2}:  "4",
+		{0, 5}:  "0",
+		NaN:     "NaN",
+	}
+
+	for src, dst := range tests {
+		assert.Equal(t, dst, src.FormatMixed())
+	}
+}
+
+func TestFormatRepeating(t *testing.T) {
+	tests := map[Value]string{
+		{1, 3}:   "0.(3)",
+		{-1, 3}:  "-0.(3)",
+		{1, 6}:   "0.1(6)",
+		{1, 4}:   "0.25",
+		{22, 7}:  "3.(142857)",
+		{5, 1}:   "5",
+		{1, 12}:  "0.08(3)",
+		{7, 990}: "0.0(07)",
+		NaN:      "NaN",
+	}
+
+	for src, dst := range tests {
+		assert.Equal(t, dst, src.FormatRepeating())
+	}
+
+	res := Value{Num: 1, Div: math.MaxInt64}.FormatRepeating()
+	assert.Len(t, res, len("0.")+MaxRepeatingDigits+len("..."))
+}
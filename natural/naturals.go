@@ -3,19 +3,39 @@ package natural
 import (
 	"fmt"
 	"math"
+	"math/big"
 )
 
+// Value is natural fraction Num/Div.
+//
+// Canonical form of the fraction has positive denominator and is reduced,
+// so zero is represented as 0/1. Fraction with zero denominator is invalid (NaN).
+// Values created by New and returned by all operations are canonical, except Divisor and
+// NewFromFloatWithDivisor, which keep the requested denominator.
+// Operations accept non-canonical literals (e.g. Value{Num: 2, Div: -4}) and normalize them.
+// Any operation with NaN returns NaN, comparisons with NaN are false.
 type Value struct {
 	Num int64
 	Div int64
 }
 
+// New is constructor for creating canonical natural fraction.
+func New(num, div int64) (Value, error) {
+	if div == 0 {
+		return NaN, ErrDivisionByZero
+	}
+	return Value{Num: num, Div: div}.Simplify(), nil
+}
+
 // Get literal representation of natural fraction
 func (a Value) String() string {
+	if a.IsNaN() {
+		return "NaN"
+	}
 	return fmt.Sprintf("%d/%d", a.Num, a.Div)
 }
 
-// Int representation of natural fraction
+// Int representation of natural fraction (truncated toward zero). NaN is 0.
 func (a Value) Int() int64 {
 	if a.Div == 0 {
 		return 0
@@ -26,51 +46,49 @@ func (a Value) Int() int64 {
 // Float representation of natural fraction
 func (a Value) Float() float64 {
 	if a.Div == 0 {
-		return 0
+		return math.NaN()
 	}
 	return float64(a.Num) / float64(a.Div)
 }
 
+// IsNaN checks if natural fraction is invalid (has zero denominator)
+func (a Value) IsNaN() bool {
+	return a.Div == 0
+}
+
 // IsZero checks if natural fraction is zero
 func (a Value) IsZero() bool {
-	return a.Num <= 0 || a.Div == 0
+	return a.Num == 0 && a.Div != 0
 }
 
 // IsEqual checks if a is equal to b
 func (a Value) IsEqual(b Value) bool {
-	if a.Div == 0 && b.Div == 0 {
-		return a.Num == b.Num
-	}
-	_, ka, kb := kab(a.Div, b.Div)
-	return a.Num*ka == b.Num*kb
+	res, ok := a.compare(b)
+	return ok && res == 0
 }
 
 // IsLessThan checks if a is less than b
 func (a Value) IsLessThan(b Value) bool {
-	if a.Div == 0 && b.Div == 0 {
-		return a.Num < b.Num
-	}
-	_, ka, kb := kab(a.Div, b.Div)
-	return a.Num*ka < b.Num*kb
+	res, ok := a.compare(b)
+	return ok && res < 0
 }
 
 // IsGreaterThan checks if a is greater than b
 func (a Value) IsGreaterThan(b Value) bool {
-	if a.Div == 0 && b.Div == 0 {
-		return a.Num > b.Num
-	}
-	_, ka, kb := kab(a.Div, b.Div)
-	return a.Num*ka > b.Num*kb
+	res, ok := a.compare(b)
+	return ok && res > 0
 }
 
 // LessOrEqualThan checks if a is less or equal to b
 func (a Value) IsLessOrEqualThan(b Value) bool {
-	return !a.IsGreaterThan(b)
+	res, ok := a.compare(b)
+	return ok && res <= 0
 }
 
 // GreaterOrEqualThan checks if a is greater or equal to b
 func (a Value) IsGreaterOrEqualThan(b Value) bool {
-	return !a.IsLessThan(b)
+	res, ok := a.compare(b)
+	return ok && res >= 0
 }
 
 // IsNotEqual checks if a is not equal to b
@@ -78,8 +96,25 @@ func (a Value) IsNotEqual(b Value) bool {
 	return !a.IsEqual(b)
 }
 
+// compare returns sign of a-b. It reports false, if one of values is NaN.
+func (a Value) compare(b Value) (int, bool) {
+	if a.IsNaN() || b.IsNaN() {
+		return 0, false
+	}
+	a = a.Simplify()
+	b = b.Simplify()
+	if a == b {
+		return 0, true
+	}
+	// Compare a.Num*b.Div with b.Num*a.Div without overflow
+	x := new(big.Int).Mul(big.NewInt(a.Num), big.NewInt(b.Div))
+	y := new(big.Int).Mul(big.NewInt(b.Num), big.NewInt(a.Div))
+	return x.Cmp(y), true
+}
+
 // Negate returns negative natural fraction
 func (a Value) Negate() Value {
+	a = a.Simplify()
 	return Value{
 		Num: -a.Num,
 		Div: a.Div,
@@ -88,13 +123,14 @@ func (a Value) Negate() Value {
 
 // Abs returns absolute value of natural fraction
 func (a Value) Abs() Value {
+	a = a.Simplify()
 	return Value{
 		Num: abs(a.Num),
-		Div: abs(a.Div),
+		Div: a.Div,
 	}
 }
 
-// Sign returns sign of natural fraction
+// Sign returns sign of natural fraction (1 for zero and positive values, -1 for negative values)
 func (a *Value) Sign() int64 {
 	if a.Num < 0 {
 		if a.Div < 0 {
@@ -115,90 +151,109 @@ func (a Value) Scale(scale int64) Value {
 	return Value{
 		Num: a.Num * scale,
 		Div: a.Div,
-	}
+	}.Simplify()
 }
 
 // Add natural fractions
 func (a Value) Add(b Value) Value {
-	if a.Div == 0 && b.Div == 0 {
-		return Value{Num: a.Num + b.Num}
+	if a.IsNaN() || b.IsNaN() {
+		return NaN
 	}
+	a = a.Simplify()
+	b = b.Simplify()
 	div, ka, kb := kab(a.Div, b.Div)
 	return Value{
 		Num: a.Num*ka + b.Num*kb,
 		Div: div,
-	}
+	}.Simplify()
 }
 
 // Subtract natural fractions
 func (a Value) Subtract(b Value) Value {
-	if a.Div == 0 && b.Div == 0 {
-		return Value{Num: a.Num - b.Num, Div: 1}
+	if a.IsNaN() || b.IsNaN() {
+		return NaN
 	}
+	a = a.Simplify()
+	b = b.Simplify()
 	div, ka, kb := kab(a.Div, b.Div)
 	return Value{
 		Num: a.Num*ka - b.Num*kb,
 		Div: div,
-	}
+	}.Simplify()
 }
 
 // Multiply natural fractions
 func (a Value) Multiple(b Value) Value {
-	if a.Div == 0 && b.Div == 0 {
-		return Value{Num: a.Num * b.Num}
-	}
-	ad := coalesce(a.Div, 1)
-	bd := coalesce(b.Div, 1)
+	if a.IsNaN() || b.IsNaN() {
+		return NaN
+	}
+	a = a.Simplify()
+	b = b.Simplify()
+	// Cross-cancelling reduces risk of overflow
+	g1 := coalesce(gcd(a.Num, b.Div), 1)
+	g2 := coalesce(gcd(b.Num, a.Div), 1)
 	res := Value{
-		Num: a.Num * b.Num,
-		Div: ad * bd,
+		Num: (a.Num / g1) * (b.Num / g2),
+		Div: (a.Div / g2) * (b.Div / g1),
 	}
 	return res.Simplify()
 }
 
-// Divide natural fractions
+// Divide natural fractions. Division by zero returns NaN.
 func (a Value) Divide(b Value) Value {
-	if a.Div == 0 && b.Div == 0 {
-		return Value{Num: a.Num, Div: b.Num}
-	}
-	ad := coalesce(a.Div, 1)
-	bd := coalesce(b.Div, 1)
+	if a.IsNaN() || b.IsNaN() || b.Num == 0 {
+		return NaN
+	}
+	a = a.Simplify()
+	b = b.Simplify()
+	// Cross-cancelling reduces risk of overflow
+	g1 := coalesce(gcd(a.Num, b.Num), 1)
+	g2 := coalesce(gcd(a.Div, b.Div), 1)
 	res := Value{
-		Num: a.Num * bd,
-		Div: b.Num * ad,
+		Num: (a.Num / g1) * (b.Div / g2),
+		Div: (b.Num / g1) * (a.Div / g2),
 	}
 	return res.Simplify()
 }
 
-// Привести число b к знаменателю div
+// Привести число b к знаменателю div.
+// Result is not reduced, because it has the requested denominator.
 func (a Value) Divisor(div int64) (Value, bool) {
 	aa := a.Simplify()
-	if aa.Div == 0 && div == 0 {
-		return Value{Num: aa.Num, Div: 1}, true
+	if aa.IsNaN() || div == 0 {
+		return aa, false
+	}
+	if div < 0 {
+		div = -div
 	}
-	ad := coalesce(aa.Div, 1)
-	div = coalesce(div, 1)
-	if abs(ad) == abs(div) {
+	if aa.Div == div {
 		return aa, true
 	}
-	if abs(ad) > abs(div) {
+	if aa.Div > div {
 		return aa, false
 	}
-	div2 := gcd(ad, div)
-	if div2 != ad && div2 != div {
-		return a, false
+	if div%aa.Div != 0 {
+		return aa, false
 	}
 	return Value{
-		Num: aa.Num * div / ad,
+		Num: aa.Num * (div / aa.Div),
 		Div: div,
 	}, true
 }
 
-// Simplify natural fraction
+// Simplify returns canonical form of natural fraction
 func (a Value) Simplify() Value {
 	if a.Div == 0 {
+		return NaN
+	}
+	if a.Num == 0 {
 		return Zero
 	}
+	if a.Num == math.MinInt64 || a.Div == math.MinInt64 {
+		// Component can not be negated, so fraction is reduced exactly (NaN, if it does not fit int64)
+		res, _ := NewBigFromRat(new(big.Rat).SetFrac(big.NewInt(a.Num), big.NewInt(a.Div))).Value()
+		return res
+	}
 	div := gcd(a.Num, a.Div)
 	return Value{
 		Num: a.Sign() * abs(a.Num) / div,
@@ -206,28 +261,138 @@ func (a Value) Simplify() Value {
 	}
 }
 
+// Key returns canonical form of natural fraction, that can be used as map key.
+// Equal fractions (e.g. 1/2 and 2/4) have the same key, all invalid fractions have key NaN.
+func (a Value) Key() Value {
+	return a.Simplify()
+}
+
 // Truncate return truncated natural value without fractional part
 func (a Value) Truncate() Value {
-	if a.Div == 0 {
+	a = a.Simplify()
+	if a.IsNaN() {
 		return a
 	}
 
 	return Value{
-		Num: (a.Num / a.Div) * a.Div,
-		Div: a.Div,
+		Num: a.Num / a.Div,
+		Div: 1,
 	}
 }
 
-// Fraction return fractional part of natural value
+// Fraction return fractional part of natural value (has the same sign as the value)
 func (a Value) Fraction() Value {
-	if a.Div == 0 {
+	a = a.Simplify()
+	if a.IsNaN() {
 		return a
 	}
 
 	return Value{
 		Num: a.Num % a.Div,
 		Div: a.Div,
+	}.Simplify()
+}
+
+// Cmp compares natural fractions and returns -1, 0 or 1.
+// NaN is considered equal to NaN and less than any other value, so Cmp defines total order.
+func (a Value) Cmp(b Value) int {
+	res, ok := a.compare(b)
+	if ok {
+		return res
+	}
+	switch {
+	case a.IsNaN() && b.IsNaN():
+		return 0
+	case a.IsNaN():
+		return -1
+	default:
+		return 1
+	}
+}
+
+// Min returns the least of natural fractions. Returns NaN, if one of them is NaN.
+func (a Value) Min(b Value) Value {
+	if a.IsNaN() || b.IsNaN() {
+		return NaN
+	}
+	if b.IsLessThan(a) {
+		return b.Simplify()
 	}
+	return a.Simplify()
+}
+
+// Max returns the greatest of natural fractions. Returns NaN, if one of them is NaN.
+func (a Value) Max(b Value) Value {
+	if a.IsNaN() || b.IsNaN() {
+		return NaN
+	}
+	if b.IsGreaterThan(a) {
+		return b.Simplify()
+	}
+	return a.Simplify()
+}
+
+// Reciprocal returns 1/a. Reciprocal of zero is NaN.
+func (a Value) Reciprocal() Value {
+	a = a.Simplify()
+	if a.IsNaN() || a.Num == 0 {
+		return NaN
+	}
+	return Value{
+		Num: a.Div,
+		Div: a.Num,
+	}.Simplify()
+}
+
+// Pow raises natural fraction to the integer power.
+// Returns NaN on overflow and for negative powers of zero.
+func (a Value) Pow(n int) Value {
+	a = a.Simplify()
+	if a.IsNaN() {
+		return NaN
+	}
+	if n < 0 {
+		return a.Reciprocal().Pow(-n)
+	}
+
+	res := Value{Num: 1, Div: 1}
+	base := a
+	for n > 0 {
+		var err error
+		if n&1 == 1 {
+			res, err = res.MultipleChecked(base)
+			if err != nil {
+				return NaN
+			}
+		}
+		n >>= 1
+		if n > 0 {
+			base, err = base.MultipleChecked(base)
+			if err != nil {
+				return NaN
+			}
+		}
+	}
+	return res
+}
+
+// Mod returns remainder of division a by b truncated toward zero (a - b*trunc(a/b)).
+// Result has the same sign as a, like operator % for integers. Returns NaN, if b is zero or result overflows int64.
+func (a Value) Mod(b Value) Value {
+	a = a.Simplify()
+	b = b.Simplify()
+	if a.IsNaN() || b.IsNaN() || b.Num == 0 {
+		return NaN
+	}
+	// a/b = (a.Num*b.Div) / (b.Num*a.Div), remainder is taken over common denominator a.Div*b.Div
+	x := new(big.Int).Mul(big.NewInt(a.Num), big.NewInt(b.Div))
+	y := new(big.Int).Mul(big.NewInt(b.Num), big.NewInt(a.Div))
+	div := new(big.Int).Mul(big.NewInt(a.Div), big.NewInt(b.Div))
+	res, err := NewBigFromRat(new(big.Rat).SetFrac(x.Rem(x, y), div)).Value()
+	if err != nil {
+		return NaN
+	}
+	return res
 }
 
 // Модуль числа
@@ -248,12 +413,12 @@ func gcd(a, b int64) int64 {
 
 // Наименьшее общее кратное
 func lcm(a, b int64) int64 {
-	return abs(a*b) / gcd(a, b)
+	return abs(a / gcd(a, b) * b)
 }
 
 func kab(a, b int64) (int64, int64, int64) {
-	a = coalesce(a, 1)
-	b = coalesce(b, 1)
+	a = abs(a)
+	b = abs(b)
 	div := lcm(a, b)
 	return div, div / a, div / b
 }
@@ -280,7 +445,14 @@ func gcd(a, b int) int {
 */
 
 // Преобразование вещественного числа к знаменателю hasDiv
+// Результат не сокращается (знаменатель равен abs(hasDiv)). NaN и бесконечности преобразуются в NaN.
 func NewFromFloatWithDivisor(want float64, hasDiv int64) Value {
+	if hasDiv == 0 || math.IsNaN(want) || math.IsInf(want, 0) {
+		return NaN
+	}
+	if hasDiv < 0 {
+		want, hasDiv = -want, -hasDiv
+	}
 	num := int64(math.Round(want * float64(hasDiv)))
 	return Value{
 		Num: num,
@@ -288,70 +460,94 @@ func NewFromFloatWithDivisor(want float64, hasDiv int64) Value {
 	}
 }
 
-// Преобразование числа в натуральную дробь с указанной точностью
+// Преобразование числа в натуральную дробь с указанной точностью.
+// Returns fraction with the smallest denominator, that differs from num not more than epsilon,
+// numerator is chosen to be the closest to num (2.7 with epsilon 1 is 3/1, not 2/1).
+// See also NewFromFloatMaxDenominator. Result is canonical. NaN and infinities are converted into NaN.
 func NewFromFloat(num float64, epsilon float64) Value {
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return NaN
+	}
 	if num < 0 {
-		return newFromFloat(math.Abs(num), epsilon).Negate()
+		return NewFromFloat(-num, epsilon).Negate()
 	}
-	intval := int64(math.Floor(num))
-	res := newFromFloat(num-float64(intval), epsilon)
-	return res.Add(Value{intval * res.Div, res.Div})
-}
-
-// https://prog-cpp.ru/fraction/
-func newFromFloat(num float64, epsilon float64) Value {
-	var a int64 = 1
-	var b int64 = 1
-
-	if num < epsilon {
-		return Value{0, 1}
+	if num >= maxConvertible {
+		return NaN
 	}
 
-	// Поиск начального приближения
-	c := 1.0
-	for {
-		b++
-		c = float64(a) / float64(b)
-		if (num - c) > 0 {
-			break
+	it := newConvergents(num)
+	for it.Next() {
+		if math.Abs(num-it.Value().Float()) > epsilon {
+			continue
+		}
+		if it.term == 0 {
+			return closestWithDivisor(num, it.Value())
+		}
+		// Look for semiconvergent with smaller denominator between previous convergents
+		lo, hi := int64(1), it.term
+		for lo < hi {
+			m := lo + (hi-lo)/2
+			if math.Abs(num-it.semiconvergent(m).Float()) <= epsilon {
+				hi = m
+			} else {
+				lo = m + 1
+			}
 		}
+		return closestWithDivisor(num, it.semiconvergent(lo))
 	}
+	return it.Value()
+}
 
-	if (num - c) < epsilon {
-		return Value{Num: a, Div: b}
+// closestWithDivisor returns the closest to num fraction with the same denominator as v.
+func closestWithDivisor(num float64, v Value) Value {
+	p := math.Round(num * float64(v.Div))
+	if p >= maxConvertible {
+		return v
+	}
+	res := Value{Num: int64(p), Div: v.Div}
+	if math.Abs(num-res.Float()) < math.Abs(num-v.Float()) {
+		return res.Simplify()
 	}
+	return v
+}
 
-	b--
-	c = float64(a) / float64(b)
-	if (num - c) > -epsilon {
-		return Value{Num: a, Div: b}
+// NewFromFloatMaxDenominator returns the closest to num fraction with denominator not greater than maxDiv.
+// Result is canonical. NaN and infinities are converted into NaN.
+func NewFromFloatMaxDenominator(num float64, maxDiv int64) Value {
+	if math.IsNaN(num) || math.IsInf(num, 0) {
+		return NaN
+	}
+	if num < 0 {
+		return NewFromFloatMaxDenominator(-num, maxDiv).Negate()
+	}
+	if num >= maxConvertible {
+		return NaN
+	}
+	if maxDiv < 1 {
+		maxDiv = 1
 	}
 
-	// Уточнение
-	var mn int64 = 2 // множитель для начального приближения
-	for i := 1; i < 20000; i++ {
-		cc := a * mn
-		zz := b * mn
-		for {
-			zz++
-			c = float64(cc) / float64(zz)
-			if (num - c) > 0 {
-				break
-			}
-		}
-		if (num - c) < epsilon {
-			return Value{Num: cc, Div: zz}
+	it := newConvergents(num)
+	for it.Next() {
+		if it.k <= maxDiv {
+			continue
 		}
-
-		zz--
-		c = float64(cc) / float64(zz)
-		if (num - c) > -epsilon {
-			return Value{Num: cc, Div: zz}
+		// Best approximation is either previous convergent or semiconvergent with the largest allowed denominator
+		best := Value{Num: it.h1, Div: it.k1}
+		m := (maxDiv - it.k2) / it.k1
+		if m > 0 {
+			bound := it.semiconvergent(m)
+			if math.Abs(num-bound.Float()) < math.Abs(num-best.Float()) {
+				best = bound
+			}
 		}
-		mn++
+		return best
 	}
-
-	return Value{Num: a, Div: b}
+	return it.Value()
 }
 
-var Zero = Value{Num: 0, Div: 0}
+// Zero is canonical zero
+var Zero = Value{Num: 0, Div: 1}
+
+// NaN is invalid natural fraction
+var NaN = Value{Num: 0, Div: 0}
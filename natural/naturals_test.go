@@ -2,6 +2,7 @@ package natural
 
 import (
 	assert "github.com/stretchr/testify/require"
+	"math"
 	"testing"
 )
 
@@ -96,6 +97,41 @@ func TestSimplify(t *testing.T) {
 			a:    Value{Num: -5, Div: -15},
 			c:    Value{Num: 1, Div: 3},
 		},
+		{
+			name: "5/-15 -> -1/3",
+			a:    Value{Num: 5, Div: -15},
+			c:    Value{Num: -1, Div: 3},
+		},
+		{
+			name: "0/-7 -> 0/1",
+			a:    Value{Num: 0, Div: -7},
+			c:    Value{Num: 0, Div: 1},
+		},
+		{
+			name: "3/0 -> NaN",
+			a:    Value{Num: 3, Div: 0},
+			c:    NaN,
+		},
+		{
+			name: "1/MinInt64 -> NaN",
+			a:    Value{Num: 1, Div: math.MinInt64},
+			c:    NaN,
+		},
+		{
+			name: "MinInt64/-1 -> NaN",
+			a:    Value{Num: math.MinInt64, Div: -1},
+			c:    NaN,
+		},
+		{
+			name: "MinInt64/2 -> MinInt64/2/1",
+			a:    Value{Num: math.MinInt64, Div: 2},
+			c:    Value{Num: math.MinInt64 / 2, Div: 1},
+		},
+		{
+			name: "MinInt64/MinInt64 -> 1/1",
+			a:    Value{Num: math.MinInt64, Div: math.MinInt64},
+			c:    Value{Num: 1, Div: 1},
+		},
 	}
 
 	for _, test := range tests {
@@ -141,6 +177,18 @@ func TestUnary(t *testing.T) {
 			c:    Value{Num: 5, Div: 3},
 			act:  "abs",
 		},
+		{
+			name: "2/-6 -> 1/3",
+			a:    Value{Num: 2, Div: -6},
+			c:    Value{Num: 1, Div: 3},
+			act:  "neg",
+		},
+		{
+			name: "NaN -> NaN",
+			a:    NaN,
+			c:    NaN,
+			act:  "abs",
+		},
 	}
 
 	for _, test := range tests {
@@ -167,10 +215,10 @@ func TestBinary(t *testing.T) {
 
 	tests := []*Test{
 		{
-			name: "0/1 + 0/10 = 0/0",
+			name: "0/1 + 0/10 = 0/1",
 			a:    Value{Num: 0, Div: 1},
 			b:    Value{Num: 0, Div: 10},
-			c:    Value{Num: 0, Div: 10},
+			c:    Value{Num: 0, Div: 1},
 			act:  "add",
 		},
 		{
@@ -189,10 +237,11 @@ func TestBinary(t *testing.T) {
 		},
 
 		{
-			name: "0/3 - 0/5 = 0/0",
+			name: "0/3 - 0/5 = 0/1",
 			a:    Value{Num: 0, Div: 3},
 			b:    Value{Num: 0, Div: 5},
-			c:    Value{Num: 0, Div: 0},
+			c:    Value{Num: 0, Div: 1},
+			act:  "sub",
 		},
 		{
 			name: "5/3  - 2/5 = 31/15",
@@ -203,7 +252,7 @@ func TestBinary(t *testing.T) {
 		},
 
 		{
-			name: "0/3 * 0/5 = 0/0",
+			name: "0/3 * 0/5 = 0/1",
 			a:    Value{Num: 0, Div: 3},
 			b:    Value{Num: 0, Div: 5},
 			c:    Value{Num: 0, Div: 1},
@@ -218,7 +267,7 @@ func TestBinary(t *testing.T) {
 		},
 
 		{
-			name: "0/3 / 0/5 = 0/0",
+			name: "0/3 / 0/5 = NaN",
 			a:    Value{Num: 0, Div: 3},
 			b:    Value{Num: 0, Div: 5},
 			c:    Value{Num: 0, Div: 0},
@@ -231,6 +280,34 @@ func TestBinary(t *testing.T) {
 			c:    Value{Num: 25, Div: 6},
 			act:  "div",
 		},
+		{
+			name: "1/2 / -1/4 = -2/1",
+			a:    Value{Num: 1, Div: 2},
+			b:    Value{Num: -1, Div: 4},
+			c:    Value{Num: -2, Div: 1},
+			act:  "div",
+		},
+		{
+			name: "1/-2 + 1/4 = -1/4",
+			a:    Value{Num: 1, Div: -2},
+			b:    Value{Num: 1, Div: 4},
+			c:    Value{Num: -1, Div: 4},
+			act:  "add",
+		},
+		{
+			name: "NaN + 1/4 = NaN",
+			a:    NaN,
+			b:    Value{Num: 1, Div: 4},
+			c:    NaN,
+			act:  "add",
+		},
+		{
+			name: "1/4 * NaN = NaN",
+			a:    Value{Num: 1, Div: 4},
+			b:    NaN,
+			c:    NaN,
+			act:  "mul",
+		},
 	}
 
 	for _, test := range tests {
@@ -277,9 +354,112 @@ func TestNaturalFromFloat(t *testing.T) {
 	}
 }
 
+func TestNaturalFromFloat_NaN(t *testing.T) {
+	assert.Equal(t, NaN, NewFromFloat(math.NaN(), 0.001))
+	assert.Equal(t, NaN, NewFromFloat(math.Inf(1), 0.001))
+	assert.Equal(t, NaN, NewFromFloatWithDivisor(0.5, 0))
+	assert.Equal(t, Value{Num: -2, Div: 4}, NewFromFloatWithDivisor(0.5, -4))
+}
+
 func TestNatural_Divisor(t *testing.T) {
 	a := Value{1, 5}
 	actual, ok := a.Divisor(3)
 	assert.Equal(t, false, ok)
 	assert.Equal(t, Value{1, 5}, actual)
 }
+
+func TestNew(t *testing.T) {
+	type Test struct {
+		name string
+		num  int64
+		div  int64
+		c    Value
+		err  error
+	}
+
+	tests := []*Test{
+		{
+			name: "2/4 -> 1/2",
+			num:  2,
+			div:  4,
+			c:    Value{Num: 1, Div: 2},
+		},
+		{
+			name: "3/-9 -> -1/3",
+			num:  3,
+			div:  -9,
+			c:    Value{Num: -1, Div: 3},
+		},
+		{
+			name: "0/5 -> 0/1",
+			num:  0,
+			div:  5,
+			c:    Zero,
+		},
+		{
+			name: "1/0 -> error",
+			num:  1,
+			div:  0,
+			c:    NaN,
+			err:  ErrDivisionByZero,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := New(test.num, test.div)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.c, actual)
+		})
+	}
+}
+
+func TestNaN(t *testing.T) {
+	a := Value{Num: 1, Div: 2}
+	assert.True(t, NaN.IsNaN())
+	assert.False(t, NaN.IsZero())
+	assert.True(t, Zero.IsZero())
+	assert.False(t, NaN.IsEqual(NaN))
+	assert.True(t, NaN.IsNotEqual(NaN))
+	assert.False(t, a.IsLessThan(NaN))
+	assert.False(t, a.IsGreaterOrEqualThan(NaN))
+	assert.True(t, math.IsNaN(NaN.Float()))
+	assert.Equal(t, int64(0), NaN.Int())
+	assert.Equal(t, "NaN", NaN.String())
+	assert.Equal(t, NaN, a.Divide(Zero))
+}
+
+func TestCompare(t *testing.T) {
+	assert.True(t, Value{Num: 1, Div: 2}.IsEqual(Value{Num: -2, Div: -4}))
+	assert.True(t, Value{Num: 1, Div: -2}.IsLessThan(Value{Num: 1, Div: 3}))
+	assert.True(t, Value{Num: math.MaxInt64, Div: 3}.IsGreaterThan(Value{Num: math.MaxInt64 - 1, Div: 3}))
+	assert.True(t, Value{Num: 1, Div: 3}.IsLessOrEqualThan(Value{Num: 2, Div: 6}))
+}
+
+func TestArithmetic(t *testing.T) {
+	assert.Equal(t, -1, Value{1, 3}.Cmp(Value{1, 2}))
+	assert.Equal(t, 0, Value{2, 4}.Cmp(Value{1, 2}))
+	assert.Equal(t, 1, Value{1, 2}.Cmp(Value{-1, 2}))
+	assert.Equal(t, -1, NaN.Cmp(Value{-1, 2}))
+	assert.Equal(t, 0, NaN.Cmp(NaN))
+
+	assert.Equal(t, Value{1, 3}, Value{1, 3}.Min(Value{2, 4}))
+	assert.Equal(t, Value{1, 2}, Value{1, 3}.Max(Value{2, 4}))
+	assert.Equal(t, NaN, Value{1, 3}.Max(NaN))
+
+	assert.Equal(t, Value{-3, 2}, Value{-2, 3}.Reciprocal())
+	assert.Equal(t, NaN, Zero.Reciprocal())
+
+	assert.Equal(t, Value{8, 27}, Value{2, 3}.Pow(3))
+	assert.Equal(t, Value{9, 4}, Value{2, 3}.Pow(-2))
+	assert.Equal(t, Value{1, 1}, Value{2, 3}.Pow(0))
+	assert.Equal(t, NaN, Zero.Pow(-1))
+	assert.Equal(t, NaN, Value{2, 1}.Pow(64))
+
+	assert.Equal(t, Value{1, 6}, Value{7, 6}.Mod(Value{1, 2}))
+	assert.Equal(t, Value{-1, 6}, Value{-7, 6}.Mod(Value{1, 2}))
+	assert.Equal(t, Value{1, 4}, Value{9, 4}.Mod(Value{1, 1}))
+	assert.Equal(t, NaN, Value{9, 4}.Mod(Zero))
+	assert.Equal(t, Value{math.MaxInt64, 6}, Value{math.MaxInt64, 2}.Mod(Value{math.MaxInt64, 3}))
+	assert.Equal(t, NaN, Value{math.MaxInt64 - 1, math.MaxInt64}.Mod(Value{1, math.MaxInt64 - 1}))
+}
@@ -0,0 +1,109 @@
+package natural
+
+import (
+	"math/big"
+)
+
+// RoundingMode specifies how fractions are rounded to integers.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest integer, halves are rounded away from zero (2.5 -> 3, -2.5 -> -3).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest integer, halves are rounded to even integer (2.5 -> 2, 3.5 -> 4).
+	RoundHalfEven
+	// RoundTowardZero discards fractional part (2.7 -> 2, -2.7 -> -2).
+	RoundTowardZero
+	// RoundFloor rounds toward negative infinity (2.7 -> 2, -2.7 -> -3).
+	RoundFloor
+	// RoundCeiling rounds toward positive infinity (2.7 -> 3, -2.7 -> -2).
+	RoundCeiling
+)
+
+func (that RoundingMode) String() string {
+	switch that {
+	case RoundHalfUp:
+		return "half-up"
+	case RoundHalfEven:
+		return "half-even"
+	case RoundTowardZero:
+		return "toward-zero"
+	case RoundFloor:
+		return "floor"
+	case RoundCeiling:
+		return "ceiling"
+	default:
+		return "unknown"
+	}
+}
+
+// Floor returns the largest integer not greater than a.
+func (a Value) Floor() Value {
+	return a.Round(RoundFloor)
+}
+
+// Ceil returns the smallest integer not less than a.
+func (a Value) Ceil() Value {
+	return a.Round(RoundCeiling)
+}
+
+// Round rounds natural fraction to integer by the mode.
+func (a Value) Round(mode RoundingMode) Value {
+	return a.RoundToDenominator(1, mode)
+}
+
+// RoundToDenominator rounds natural fraction to the nearest multiple of 1/div by the mode.
+// Result is canonical, so its denominator can be a divisor of div (use Divisor for restore it).
+// Returns NaN, if div is not positive or result overflows int64.
+// Example: 1/3 rounded to denominator 100 is 33/100.
+func (a Value) RoundToDenominator(div int64, mode RoundingMode) Value {
+	a = a.Simplify()
+	if a.IsNaN() || div <= 0 {
+		return NaN
+	}
+	if a.Div == 1 || div%a.Div == 0 {
+		return a
+	}
+
+	num := new(big.Int).Mul(big.NewInt(a.Num), big.NewInt(div))
+	res := roundQuotient(num, big.NewInt(a.Div), mode)
+	if !res.IsInt64() {
+		return NaN
+	}
+	return Value{Num: res.Int64(), Div: div}.Simplify()
+}
+
+// roundQuotient returns num/div rounded to integer by the mode. Divisor must be positive.
+func roundQuotient(num, div *big.Int, mode RoundingMode) *big.Int {
+	// Euclidean division gives floor for positive divisor
+	q, r := new(big.Int).DivMod(num, div, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	up := false
+	switch mode {
+	case RoundFloor:
+	case RoundCeiling:
+		up = true
+	case RoundTowardZero:
+		up = num.Sign() < 0
+	default:
+		// Compare remainder with half of divisor
+		switch new(big.Int).Lsh(r, 1).Cmp(div) {
+		case 1:
+			up = true
+		case 0:
+			if mode == RoundHalfEven {
+				up = q.Bit(0) == 1
+			} else {
+				up = num.Sign() > 0
+			}
+		}
+	}
+
+	if up {
+		q.Add(q, big.NewInt(1))
+	}
+	return q
+}
@@ -0,0 +1,48 @@
+package natural
+
+import (
+	assert "github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	type Test struct {
+		src  Value
+		mode RoundingMode
+		dst  Value
+	}
+
+	tests := map[string]Test{
+		"5/2 half-up":          {src: Value{5, 2}, mode: RoundHalfUp, dst: Value{3, 1}},
+		"-5/2 half-up":         {src: Value{-5, 2}, mode: RoundHalfUp, dst: Value{-3, 1}},
+		"5/2 half-even":        {src: Value{5, 2}, mode: RoundHalfEven, dst: Value{2, 1}},
+		"7/2 half-even":        {src: Value{7, 2}, mode: RoundHalfEven, dst: Value{4, 1}},
+		"-5/2 half-even":       {src: Value{-5, 2}, mode: RoundHalfEven, dst: Value{-2, 1}},
+		"8/3 half-even":        {src: Value{8, 3}, mode: RoundHalfEven, dst: Value{3, 1}},
+		"7/3 half-up":          {src: Value{7, 3}, mode: RoundHalfUp, dst: Value{2, 1}},
+		"27/10 toward zero":    {src: Value{27, 10}, mode: RoundTowardZero, dst: Value{2, 1}},
+		"-27/10 toward zero":   {src: Value{-27, 10}, mode: RoundTowardZero, dst: Value{-2, 1}},
+		"-27/10 floor":         {src: Value{-27, 10}, mode: RoundFloor, dst: Value{-3, 1}},
+		"-27/10 ceiling":       {src: Value{-27, 10}, mode: RoundCeiling, dst: Value{-2, 1}},
+		"integer stays intact": {src: Value{4, 1}, mode: RoundCeiling, dst: Value{4, 1}},
+		"NaN":                  {src: NaN, mode: RoundHalfUp, dst: NaN},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.dst, test.src.Round(test.mode))
+		})
+	}
+
+	assert.Equal(t, Value{-3, 1}, Value{-5, 2}.Floor())
+	assert.Equal(t, Value{3, 1}, Value{5, 2}.Ceil())
+}
+
+func TestRoundToDenominator(t *testing.T) {
+	assert.Equal(t, Value{33, 100}, Value{1, 3}.RoundToDenominator(100, RoundHalfUp))
+	assert.Equal(t, Value{67, 100}, Value{2, 3}.RoundToDenominator(100, RoundHalfUp))
+	assert.Equal(t, Value{1, 8}, Value{1, 8}.RoundToDenominator(1000, RoundHalfUp))
+	assert.Equal(t, Value{1, 2}, Value{5, 8}.RoundToDenominator(4, RoundHalfEven))
+	assert.Equal(t, Value{-1, 4}, Value{-1, 3}.RoundToDenominator(4, RoundHalfUp))
+	assert.Equal(t, NaN, Value{1, 3}.RoundToDenominator(0, RoundHalfUp))
+}
@@ -0,0 +1,94 @@
+package natural
+
+import (
+	"slices"
+)
+
+// Compare compares natural fractions (see Value.Cmp).
+// It can be used with slices.SortFunc and slices.BinarySearchFunc.
+func Compare(a, b Value) int {
+	return a.Cmp(b)
+}
+
+// Values is list of natural fractions, that implements sort.Interface.
+type Values []Value
+
+func (that Values) Len() int {
+	return len(that)
+}
+
+func (that Values) Less(i, j int) bool {
+	return that[i].Cmp(that[j]) < 0
+}
+
+func (that Values) Swap(i, j int) {
+	that[i], that[j] = that[j], that[i]
+}
+
+// Set is set of natural fractions. Fractions are stored in canonical form,
+// so 1/2 and 2/4 are the same member. Set is not safe for concurrent use.
+type Set struct {
+	items map[Value]struct{}
+}
+
+// Len returns count of members.
+func (that *Set) Len() int {
+	return len(that.items)
+}
+
+// Add inserts fraction into the set and reports whether it was absent.
+func (that *Set) Add(value Value) bool {
+	key := value.Key()
+	if _, ok := that.items[key]; ok {
+		return false
+	}
+	if that.items == nil {
+		that.items = make(map[Value]struct{})
+	}
+	that.items[key] = struct{}{}
+	return true
+}
+
+// Remove deletes fraction from the set and reports whether it was present.
+func (that *Set) Remove(value Value) bool {
+	key := value.Key()
+	if _, ok := that.items[key]; !ok {
+		return false
+	}
+	delete(that.items, key)
+	return true
+}
+
+// Contains checks if fraction is member of the set.
+func (that *Set) Contains(value Value) bool {
+	_, ok := that.items[value.Key()]
+	return ok
+}
+
+// Values returns members of the set in ascending order.
+func (that *Set) Values() []Value {
+	res := make([]Value, 0, len(that.items))
+	for key := range that.items {
+		res = append(res, key)
+	}
+	slices.SortFunc(res, Compare)
+	return res
+}
+
+// Range calls fn for each member in ascending order, until fn returns false.
+func (that *Set) Range(fn func(value Value) bool) {
+	for _, value := range that.Values() {
+		if !fn(value) {
+			return
+		}
+	}
+}
+
+// NewSet is constructor for creating set of fractions.
+func NewSet(values ...Value) *Set {
+	set := &Set{items: make(map[Value]struct{}, len(values))}
+	for _, value := range values {
+		set.Add(value)
+	}
+	return set
+}
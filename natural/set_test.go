@@ -0,0 +1,56 @@
+package natural
+
+import (
+	assert "github.com/stretchr/testify/require"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	groups := map[Value]int{}
+	for _, v := range []Value{{1, 2}, {2, 4}, {-3, -6}, {1, 3}, {0, 5}, {0, 1}} {
+		groups[v.Key()]++
+	}
+	assert.Equal(t, map[Value]int{{1, 2}: 3, {1, 3}: 1, {0, 1}: 2}, groups)
+	assert.Equal(t, NaN, Value{Num: 5, Div: 0}.Key())
+}
+
+func TestSort(t *testing.T) {
+	src := []Value{{1, 2}, {-1, 3}, NaN, {2, 1}, {1, 3}}
+	want := []Value{NaN, {-1, 3}, {1, 3}, {1, 2}, {2, 1}}
+
+	list := slices.Clone(src)
+	slices.SortFunc(list, Compare)
+	assert.Equal(t, want, list)
+
+	list = slices.Clone(src)
+	sort.Sort(Values(list))
+	assert.Equal(t, want, list)
+}
+
+func TestSet(t *testing.T) {
+	set := NewSet(Value{2, 4}, Value{16, 9}, Value{1, 2}, Value{-4, 3})
+	assert.Equal(t, 3, set.Len())
+	assert.True(t, set.Contains(Value{1, 2}))
+	assert.True(t, set.Contains(Value{32, 18}))
+	assert.False(t, set.Contains(Value{1, 3}))
+
+	assert.False(t, set.Add(Value{-8, 6}))
+	assert.True(t, set.Add(Value{1, 3}))
+	assert.True(t, set.Remove(Value{3, 6}))
+	assert.False(t, set.Remove(Value{1, 2}))
+
+	assert.Equal(t, []Value{{-4, 3}, {1, 3}, {16, 9}}, set.Values())
+
+	var visited []Value
+	set.Range(func(value Value) bool {
+		visited = append(visited, value)
+		return len(visited) < 2
+	})
+	assert.Equal(t, []Value{{-4, 3}, {1, 3}}, visited)
+
+	var empty Set
+	assert.True(t, empty.Add(Value{1, 1}))
+	assert.Equal(t, 1, empty.Len())
+}
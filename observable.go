@@ -0,0 +1,217 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/adverax/types/convert"
+	"strings"
+	"sync"
+)
+
+// Change describes modification of the single property.
+type Change struct {
+	Name string
+	Old  interface{}
+	New  interface{}
+}
+
+// Observer is handler of the committed changes.
+type Observer func(ctx context.Context, changes []Change)
+
+type subscription struct {
+	prefix   string
+	observer Observer
+}
+
+// Observable is wrapper of the GetterSetter, that notifies subscribers about changes.
+type Observable struct {
+	mx          sync.RWMutex
+	target      GetterSetter
+	subscribers map[int]*subscription
+	lastId      int
+}
+
+func (that *Observable) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	that.mx.RLock()
+	defer that.mx.RUnlock()
+	return that.target.GetProperty(ctx, name)
+}
+
+// SetProperty sets value of the property and notifies subscribers.
+func (that *Observable) SetProperty(ctx context.Context, name string, value interface{}) error {
+	return that.Transaction(ctx, func(tx GetterSetter) error {
+		return tx.SetProperty(ctx, name, value)
+	})
+}
+
+// Subscribe registers observer of the properties with the specified prefix.
+// Empty prefix means all properties. Observer is also notified,
+// when one of the parents of the prefix is changed.
+// Returned function cancels the subscription.
+// Example: Subscribe("$.db", observer)
+func (that *Observable) Subscribe(prefix string, observer Observer) (unsubscribe func()) {
+	that.mx.Lock()
+	defer that.mx.Unlock()
+
+	that.lastId++
+	id := that.lastId
+	that.subscribers[id] = &subscription{prefix: prefix, observer: observer}
+
+	return func() {
+		that.mx.Lock()
+		defer that.mx.Unlock()
+		delete(that.subscribers, id)
+	}
+}
+
+// Transaction executes action with buffered setter. If action succeeds,
+// all changes are applied to the target and subscribers are notified once.
+// Properties, that are set to their current values, are not reported.
+// If action or applying fails, target stays unchanged: already applied properties
+// are restored to their old values, properties, that were missing, are removed
+// (target must implement Remover, otherwise they are restored as nil).
+func (that *Observable) Transaction(ctx context.Context, action func(tx GetterSetter) error) error {
+	tx := &observableTx{owner: that, values: make(map[string]interface{})}
+	err := action(tx)
+	if err != nil {
+		return err
+	}
+
+	that.mx.Lock()
+	changes, err := that.apply(ctx, tx)
+	that.mx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	that.notify(ctx, changes)
+	return nil
+}
+
+// appliedChange is change of the target, that can be rolled back.
+type appliedChange struct {
+	Change
+	missing bool
+}
+
+// apply sets buffered values to the target or rolls back applied values on failure.
+func (that *Observable) apply(ctx context.Context, tx *observableTx) ([]Change, error) {
+	applied := make([]appliedChange, 0, len(tx.names))
+	for _, name := range tx.names {
+		value := tx.values[name]
+		old, err := that.target.GetProperty(ctx, name)
+		missing := errors.Is(err, GetErrNoMatch())
+		if err != nil && !missing {
+			err = fmt.Errorf("GetProperty %q: %w", name, err)
+			return nil, errors.Join(err, that.rollback(ctx, applied))
+		}
+		if !missing && convert.DeepEqual(old, value, convert.EqualOptions{}) {
+			continue
+		}
+		err = that.target.SetProperty(ctx, name, value)
+		if err != nil {
+			err = fmt.Errorf("SetProperty %q: %w", name, err)
+			return nil, errors.Join(err, that.rollback(ctx, applied))
+		}
+		applied = append(applied, appliedChange{
+			Change:  Change{Name: name, Old: old, New: value},
+			missing: missing,
+		})
+	}
+
+	changes := make([]Change, len(applied))
+	for i, change := range applied {
+		changes[i] = change.Change
+	}
+	return changes, nil
+}
+
+// rollback restores old values of the applied changes in reverse order.
+func (that *Observable) rollback(ctx context.Context, changes []appliedChange) error {
+	remover, _ := that.target.(Remover)
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		var err error
+		if change.missing && remover != nil {
+			err = remover.RemoveProperty(ctx, change.Name)
+		} else {
+			err = that.target.SetProperty(ctx, change.Name, change.Old)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rollback %q: %w", change.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (that *Observable) notify(ctx context.Context, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+
+	that.mx.RLock()
+	subscribers := make([]*subscription, 0, len(that.subscribers))
+	for _, s := range that.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	that.mx.RUnlock()
+
+	for _, s := range subscribers {
+		var list []Change
+		for _, change := range changes {
+			if isAffectedPath(change.Name, s.prefix) {
+				list = append(list, change)
+			}
+		}
+		if len(list) != 0 {
+			s.observer(ctx, list)
+		}
+	}
+}
+
+// NewObservable is constructor for creating Observable.
+// Example: NewObservable(json.Map{})
+func NewObservable(target GetterSetter) *Observable {
+	return &Observable{
+		target:      target,
+		subscribers: make(map[int]*subscription),
+	}
+}
+
+type observableTx struct {
+	owner  *Observable
+	values map[string]interface{}
+	names  []string
+}
+
+func (that *observableTx) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	if v, ok := that.values[name]; ok {
+		return v, nil
+	}
+	return that.owner.GetProperty(ctx, name)
+}
+
+func (that *observableTx) SetProperty(ctx context.Context, name string, value interface{}) error {
+	if _, ok := that.values[name]; !ok {
+		that.names = append(that.names, name)
+	}
+	that.values[name] = value
+	return nil
+}
+
+// isAffectedPath checks if changing of the path affects subtree with the prefix.
+func isAffectedPath(path, prefix string) bool {
+	return isSubPath(path, prefix) || isSubPath(prefix, path)
+}
+
+func isSubPath(path, prefix string) bool {
+	if prefix == "" || path == prefix {
+		return true
+	}
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	return strings.HasSuffix(prefix, ".") || path[len(prefix)] == '.'
+}
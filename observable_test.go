@@ -0,0 +1,137 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+type testMap map[string]interface{}
+
+func (that testMap) GetProperty(ctx context.Context, name string) (interface{}, error) {
+	return testGetter(that).GetProperty(ctx, name)
+}
+
+func (that testMap) SetProperty(ctx context.Context, name string, value interface{}) error {
+	that[name] = value
+	return nil
+}
+
+func (that testMap) RemoveProperty(ctx context.Context, name string) error {
+	delete(that, name)
+	return nil
+}
+
+type failingMap struct {
+	testMap
+	bad string
+}
+
+func (that failingMap) SetProperty(ctx context.Context, name string, value interface{}) error {
+	if name == that.bad {
+		return errors.New("read-only")
+	}
+	return that.testMap.SetProperty(ctx, name, value)
+}
+
+func TestObservable(t *testing.T) {
+	ctx := context.Background()
+	target := testMap{"$.db.host": "a"}
+	observable := NewObservable(target)
+
+	var all, db, cache [][]Change
+	observable.Subscribe("", func(ctx context.Context, changes []Change) { all = append(all, changes) })
+	observable.Subscribe("$.db", func(ctx context.Context, changes []Change) { db = append(db, changes) })
+	unsubscribe := observable.Subscribe("$.cache", func(ctx context.Context, changes []Change) { cache = append(cache, changes) })
+
+	require.NoError(t, observable.SetProperty(ctx, "$.db.host", "b"))
+	assert.Equal(t, [][]Change{{{Name: "$.db.host", Old: "a", New: "b"}}}, db)
+
+	err := observable.Transaction(ctx, func(tx GetterSetter) error {
+		_ = tx.SetProperty(ctx, "$.dbx", 1)
+		_ = tx.SetProperty(ctx, "$.cache.size", 10)
+		_ = tx.SetProperty(ctx, "$.cache.size", 20)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Len(t, all[1], 2)
+	assert.Len(t, db, 1)
+	assert.Equal(t, [][]Change{{{Name: "$.cache.size", Old: nil, New: 20}}}, cache)
+
+	unsubscribe()
+	err = observable.Transaction(ctx, func(tx GetterSetter) error {
+		_ = tx.SetProperty(ctx, "$.cache.size", 30)
+		return errors.New("rollback")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 20, target["$.cache.size"])
+
+	require.NoError(t, observable.SetProperty(ctx, "$.cache.size", 40))
+	assert.Len(t, cache, 1)
+	assert.Len(t, all, 3)
+}
+
+func TestObservable_Rollback(t *testing.T) {
+	ctx := context.Background()
+	target := failingMap{testMap: testMap{"a": 1, "b": 2}, bad: "c"}
+	observable := NewObservable(target)
+
+	var all [][]Change
+	observable.Subscribe("", func(ctx context.Context, changes []Change) { all = append(all, changes) })
+
+	err := observable.Transaction(ctx, func(tx GetterSetter) error {
+		_ = tx.SetProperty(ctx, "a", 10)
+		_ = tx.SetProperty(ctx, "new", 20)
+		_ = tx.SetProperty(ctx, "c", 30)
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, testMap{"a": 1, "b": 2}, target.testMap)
+	assert.Empty(t, all)
+}
+
+func TestObservable_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	observable := NewObservable(testMap{"a": 1, "b": []interface{}{"x"}})
+
+	var all [][]Change
+	observable.Subscribe("", func(ctx context.Context, changes []Change) { all = append(all, changes) })
+
+	require.NoError(t, observable.SetProperty(ctx, "a", 1))
+	require.NoError(t, observable.SetProperty(ctx, "b", []interface{}{"x"}))
+	assert.Empty(t, all)
+
+	err := observable.Transaction(ctx, func(tx GetterSetter) error {
+		_ = tx.SetProperty(ctx, "a", 1)
+		_ = tx.SetProperty(ctx, "c", nil)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]Change{{{Name: "c", Old: nil, New: nil}}}, all)
+}
+
+func TestObservable_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	observable := NewObservable(testMap{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = observable.SetProperty(ctx, "key", j)
+				_, _ = observable.GetProperty(ctx, "key")
+				_ = observable.Transaction(ctx, func(tx GetterSetter) error {
+					_, err := tx.GetProperty(ctx, "key")
+					return err
+				})
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -2,19 +2,399 @@ package ranges
 
 import "github.com/adverax/core"
 
+// Bound is kind of the range boundary.
+type Bound int
+
+const (
+	// Inclusive bound belongs to the range: [Min or Max].
+	Inclusive Bound = iota
+	// Exclusive bound does not belong to the range: (Min or Max).
+	Exclusive
+	// Unbounded side of the range is infinite, its value is ignored.
+	Unbounded
+)
+
+func (that Bound) String() string {
+	switch that {
+	case Inclusive:
+		return "inclusive"
+	case Exclusive:
+		return "exclusive"
+	case Unbounded:
+		return "unbounded"
+	default:
+		return "unknown"
+	}
+}
+
+// Range is interval between Min and Max. Each side has own kind of bound,
+// zero bounds are inclusive, so by default range is closed [Min, Max].
+// Ranges are treated as continuous: (1,2) is not empty even for integers.
+// Range with Min greater than Max is empty.
 type Range[T core.Ordered] struct {
-	Min T
-	Max T
+	Min      T
+	Max      T
+	MinBound Bound
+	MaxBound Bound
 }
 
 func (r Range[T]) Contains(value T) bool {
-	return r.Min <= value && value <= r.Max
+	return r.isAboveMin(value) && r.isBelowMax(value)
 }
 
 func (r Range[T]) Overlaps(other Range[T]) bool {
-	return r.Min <= other.Max && other.Min <= r.Max
+	_, ok := r.Intersect(other)
+	return ok
+}
+
+// IsBounded checks if both sides of the range are finite.
+func (r Range[T]) IsBounded() bool {
+	return r.MinBound != Unbounded && r.MaxBound != Unbounded
+}
+
+// IsValid checks if Min is not greater than Max. Unbounded ranges are always valid.
+func (r Range[T]) IsValid() bool {
+	return !r.IsBounded() || r.Min <= r.Max
+}
+
+// IsEmpty checks if range contains no values.
+func (r Range[T]) IsEmpty() bool {
+	if !r.IsBounded() {
+		return false
+	}
+	if r.Min == r.Max {
+		return r.MinBound == Exclusive || r.MaxBound == Exclusive
+	}
+	return r.Min > r.Max
+}
+
+// Clamp returns the nearest to value member of the range.
+// Exclusive bound is returned as is, when value is outside of it.
+// Result is undefined for empty range.
+func (r Range[T]) Clamp(value T) T {
+	if r.MinBound != Unbounded && value < r.Min {
+		return r.Min
+	}
+	if r.MaxBound != Unbounded && value > r.Max {
+		return r.Max
+	}
+	return value
+}
+
+// Intersect returns common part of ranges. It reports false, if ranges do not overlap.
+func (r Range[T]) Intersect(other Range[T]) (Range[T], bool) {
+	res := r
+	if compareMin(other, r) > 0 {
+		res.Min, res.MinBound = other.Min, other.MinBound
+	}
+	if compareMax(other, r) < 0 {
+		res.Max, res.MaxBound = other.Max, other.MaxBound
+	}
+	return res, !res.IsEmpty()
+}
+
+// Union returns range, that covers both ranges.
+// It reports false, if ranges are not contiguous (there is a gap between them),
+// because union can not be represented by single range.
+func (r Range[T]) Union(other Range[T]) (Range[T], bool) {
+	switch {
+	case r.IsEmpty():
+		return other, !other.IsEmpty()
+	case other.IsEmpty():
+		return r, true
+	case r.isBefore(other) || other.isBefore(r):
+		return Range[T]{}, false
+	}
+
+	res := r
+	if compareMin(other, r) < 0 {
+		res.Min, res.MinBound = other.Min, other.MinBound
+	}
+	if compareMax(other, r) > 0 {
+		res.Max, res.MaxBound = other.Max, other.MaxBound
+	}
+	return res, true
+}
+
+// Subtract returns parts of the range, that are not covered by other (up to two ranges).
+// Example: [1,10] - [3,5] = [1,3), (5,10].
+func (r Range[T]) Subtract(other Range[T]) []Range[T] {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(other) {
+		return []Range[T]{r}
+	}
+
+	var res []Range[T]
+	if other.MinBound != Unbounded {
+		left := r
+		left.Max, left.MaxBound = other.Min, flip(other.MinBound)
+		if compareMax(r, left) < 0 {
+			left.Max, left.MaxBound = r.Max, r.MaxBound
+		}
+		if !left.IsEmpty() {
+			res = append(res, left)
+		}
+	}
+	if other.MaxBound != Unbounded {
+		right := r
+		right.Min, right.MinBound = other.Max, flip(other.MaxBound)
+		if compareMin(r, right) > 0 {
+			right.Min, right.MinBound = r.Min, r.MinBound
+		}
+		if !right.IsEmpty() {
+			res = append(res, right)
+		}
+	}
+	return res
+}
+
+func (r Range[T]) isAboveMin(value T) bool {
+	switch r.MinBound {
+	case Unbounded:
+		return true
+	case Exclusive:
+		return r.Min < value
+	default:
+		return r.Min <= value
+	}
+}
+
+func (r Range[T]) isBelowMax(value T) bool {
+	switch r.MaxBound {
+	case Unbounded:
+		return true
+	case Exclusive:
+		return value < r.Max
+	default:
+		return value <= r.Max
+	}
+}
+
+// isBefore checks if range ends before other starts and there is a gap between them.
+func (r Range[T]) isBefore(other Range[T]) bool {
+	if r.MaxBound == Unbounded || other.MinBound == Unbounded {
+		return false
+	}
+	if r.Max == other.Min {
+		return r.MaxBound == Exclusive && other.MinBound == Exclusive
+	}
+	return r.Max < other.Min
+}
+
+// compareMin compares lower sides of ranges (-1 if a starts earlier than b).
+func compareMin[T core.Ordered](a, b Range[T]) int {
+	switch {
+	case a.MinBound == Unbounded && b.MinBound == Unbounded:
+		return 0
+	case a.MinBound == Unbounded:
+		return -1
+	case b.MinBound == Unbounded:
+		return 1
+	case a.Min != b.Min:
+		return core.Compare(a.Min, b.Min)
+	case a.MinBound == b.MinBound:
+		return 0
+	case a.MinBound == Inclusive:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareMax compares upper sides of ranges (-1 if a ends earlier than b).
+func compareMax[T core.Ordered](a, b Range[T]) int {
+	switch {
+	case a.MaxBound == Unbounded && b.MaxBound == Unbounded:
+		return 0
+	case a.MaxBound == Unbounded:
+		return 1
+	case b.MaxBound == Unbounded:
+		return -1
+	case a.Max != b.Max:
+		return core.Compare(a.Max, b.Max)
+	case a.MaxBound == b.MaxBound:
+		return 0
+	case a.MaxBound == Inclusive:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// flip returns bound of the complement side.
+func flip(bound Bound) Bound {
+	if bound == Inclusive {
+		return Exclusive
+	}
+	return Inclusive
 }
 
 func NewRange[T core.Ordered](min, max T) Range[T] {
 	return Range[T]{Min: min, Max: max}
 }
+
+// NewRangeWithBounds is constructor for creating range with custom bounds.
+func NewRangeWithBounds[T core.Ordered](min T, minBound Bound, max T, maxBound Bound) Range[T] {
+	return Range[T]{Min: min, Max: max, MinBound: minBound, MaxBound: maxBound}
+}
+
+// NewHalfOpenRange is constructor for creating range [min, max).
+func NewHalfOpenRange[T core.Ordered](min, max T) Range[T] {
+	return Range[T]{Min: min, Max: max, MaxBound: Exclusive}
+}
+
+// NewOpenRange is constructor for creating range (min, max).
+func NewOpenRange[T core.Ordered](min, max T) Range[T] {
+	return Range[T]{Min: min, Max: max, MinBound: Exclusive, MaxBound: Exclusive}
+}
+
+// AtLeast is constructor for creating range [min, +∞).
+func AtLeast[T core.Ordered](min T) Range[T] {
+	return Range[T]{Min: min, MaxBound: Unbounded}
+}
+
+// GreaterThan is constructor for creating range (min, +∞).
+func GreaterThan[T core.Ordered](min T) Range[T] {
+	return Range[T]{Min: min, MinBound: Exclusive, MaxBound: Unbounded}
+}
+
+// AtMost is constructor for creating range (-∞, max].
+func AtMost[T core.Ordered](max T) Range[T] {
+	return Range[T]{Max: max, MinBound: Unbounded}
+}
+
+// LessThan is constructor for creating range (-∞, max).
+func LessThan[T core.Ordered](max T) Range[T] {
+	return Range[T]{Max: max, MinBound: Unbounded, MaxBound: Exclusive}
+}
+
+// All is constructor for creating range (-∞, +∞).
+func All[T core.Ordered]() Range[T] {
+	return Range[T]{MinBound: Unbounded, MaxBound: Unbounded}
+}
+
+// Span returns length of the numeric range (Max - Min).
+// Span of empty and unbounded ranges is zero.
+// Span of integer range, that does not fit into T, is clamped to the largest value of T.
+func Span[T core.Numeric](r Range[T]) T {
+	if r.IsEmpty() || !r.IsBounded() {
+		return 0
+	}
+	if span := r.Max - r.Min; span >= 0 {
+		return span
+	}
+	return maxOf[T]()
+}
+
+// Expand returns range, that is extended by value in both directions.
+// Negative value shrinks the range. Unbounded sides are kept.
+// Integer bounds saturate at limits of T instead of wrapping around.
+func Expand[T core.Numeric](r Range[T], by T) Range[T] {
+	if r.MinBound != Unbounded {
+		switch v := r.Min - by; {
+		case by > 0 && v > r.Min:
+			r.Min = minOf[T]()
+		case by < 0 && v < r.Min:
+			r.Min = maxOf[T]()
+		default:
+			r.Min = v
+		}
+	}
+	if r.MaxBound != Unbounded {
+		switch v := r.Max + by; {
+		case by > 0 && v < r.Max:
+			r.Max = maxOf[T]()
+		case by < 0 && v > r.Max:
+			r.Max = minOf[T]()
+		default:
+			r.Max = v
+		}
+	}
+	return r
+}
+
+// Split divides bounded numeric range into n consecutive parts of equal length.
+// Integer ranges are divided into disjoint closed parts by count of members
+// (parts differ in size by one at most, and n is limited by count of members),
+// float ranges are divided into half-open parts [a,b), the last part keeps upper bound of the range.
+// Example: [0,9] by 3 is [0,3], [4,6], [7,9]; [0.0,1.0] by 2 is [0.0,0.5), [0.5,1.0].
+func Split[T core.Numeric](r Range[T], n int) []Range[T] {
+	if n <= 0 || r.IsEmpty() || !r.IsBounded() {
+		return nil
+	}
+
+	if !isIntegral[T]() {
+		step := (r.Max - r.Min) / T(n)
+		res := make([]Range[T], n)
+		for i := range res {
+			res[i] = Range[T]{
+				Min:      r.Min + T(i)*step,
+				Max:      r.Min + T(i+1)*step,
+				MaxBound: Exclusive,
+			}
+		}
+		res[0].MinBound = r.MinBound
+		res[n-1].Max, res[n-1].MaxBound = r.Max, r.MaxBound
+		return res
+	}
+
+	// Integer range is normalized to closed form
+	if r.MinBound == Exclusive {
+		r.Min, r.MinBound = r.Min+1, Inclusive
+	}
+	if r.MaxBound == Exclusive {
+		r.Max, r.MaxBound = r.Max-1, Inclusive
+	}
+	if r.IsEmpty() {
+		return nil
+	}
+
+	// Count of members is span+1, span is calculated in uint64 without overflow
+	span := uint64(r.Max) - uint64(r.Min)
+	if uint64(n)-1 > span {
+		n = int(span) + 1
+	}
+	size := span / uint64(n)
+	rest := span - size*uint64(n) + 1
+	if rest == uint64(n) {
+		size, rest = size+1, 0
+	}
+	res := make([]Range[T], n)
+	lo := r.Min
+	for i := range res {
+		width := size - 1
+		if uint64(i) < rest {
+			width++
+		}
+		hi := lo + T(width)
+		res[i] = Range[T]{Min: lo, Max: hi}
+		lo = hi + 1
+	}
+	return res
+}
+
+// isIntegral checks if T is integer type.
+func isIntegral[T core.Numeric]() bool {
+	var one T = 1
+	return one/2 == 0
+}
+
+// minOf returns the smallest value of integer type T.
+func minOf[T core.Numeric]() T {
+	var zero T
+	if zero-1 > zero {
+		return zero
+	}
+	return -maxOf[T]() - 1
+}
+
+// maxOf returns the largest value of integer type T.
+func maxOf[T core.Numeric]() T {
+	var res T = 1
+	for res*2+1 > res {
+		res = res*2 + 1
+	}
+	return res
+}
@@ -0,0 +1,293 @@
+package ranges
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRange_Intersect(t *testing.T) {
+	type Test struct {
+		a, b Range[int]
+		c    Range[int]
+		ok   bool
+	}
+
+	tests := map[string]Test{
+		"overlapping": {a: NewRange(1, 10), b: NewRange(5, 15), c: NewRange(5, 10), ok: true},
+		"nested":      {a: NewRange(1, 10), b: NewRange(3, 4), c: NewRange(3, 4), ok: true},
+		"touching":    {a: NewRange(1, 5), b: NewRange(5, 10), c: NewRange(5, 5), ok: true},
+		"disjoint":    {a: NewRange(1, 5), b: NewRange(6, 10), ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, ok := test.a.Intersect(test.b)
+			assert.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.c, c)
+			}
+		})
+	}
+}
+
+func TestRange_Union(t *testing.T) {
+	type Test struct {
+		a, b Range[int]
+		c    Range[int]
+		ok   bool
+	}
+
+	tests := map[string]Test{
+		"overlapping": {a: NewRange(1, 10), b: NewRange(5, 15), c: NewRange(1, 15), ok: true},
+		"touching":    {a: NewRange(1, 5), b: NewRange(5, 10), c: NewRange(1, 10), ok: true},
+		"disjoint":    {a: NewRange(1, 5), b: NewRange(7, 10), ok: false},
+		"empty":       {a: NewRange(5, 1), b: NewRange(7, 10), c: NewRange(7, 10), ok: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, ok := test.a.Union(test.b)
+			assert.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.c, c)
+			}
+		})
+	}
+}
+
+func TestRange_Subtract(t *testing.T) {
+	type Test struct {
+		a, b Range[int]
+		c    []Range[int]
+	}
+
+	tests := map[string]Test{
+		"middle":   {a: NewRange(1, 10), b: NewRange(3, 5), c: []Range[int]{NewHalfOpenRange(1, 3), NewRangeWithBounds(5, Exclusive, 10, Inclusive)}},
+		"left":     {a: NewRange(1, 10), b: NewRange(0, 5), c: []Range[int]{NewRangeWithBounds(5, Exclusive, 10, Inclusive)}},
+		"right":    {a: NewRange(1, 10), b: NewRange(5, 20), c: []Range[int]{NewHalfOpenRange(1, 5)}},
+		"open":     {a: NewRange(1, 10), b: NewOpenRange(3, 5), c: []Range[int]{NewRange(1, 3), NewRange(5, 10)}},
+		"at least": {a: NewRange(1, 10), b: AtLeast(5), c: []Range[int]{NewHalfOpenRange(1, 5)}},
+		"all":      {a: NewRange(1, 10), b: All[int](), c: nil},
+		"from all": {a: All[int](), b: NewHalfOpenRange(1, 5), c: []Range[int]{LessThan(1), AtLeast(5)}},
+		"covered":  {a: NewRange(1, 10), b: NewRange(0, 20), c: nil},
+		"disjoint": {a: NewRange(1, 10), b: NewRange(20, 30), c: []Range[int]{NewRange(1, 10)}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.c, test.a.Subtract(test.b))
+		})
+	}
+}
+
+func TestRange_Misc(t *testing.T) {
+	r := NewRange(1, 10)
+	assert.True(t, r.IsValid())
+	assert.False(t, r.IsEmpty())
+	assert.True(t, NewRange(10, 1).IsEmpty())
+	assert.True(t, NewRange(1, 1).IsValid())
+
+	assert.Equal(t, 1, r.Clamp(-5))
+	assert.Equal(t, 10, r.Clamp(50))
+	assert.Equal(t, 7, r.Clamp(7))
+
+	assert.Equal(t, 9, Span(r))
+	assert.Equal(t, 0, Span(NewRange(10, 1)))
+	assert.Equal(t, 1.5, Span(NewRange(0.5, 2.0)))
+	assert.Equal(t, int8(127), Span(NewRange[int8](-128, 127)))
+
+	assert.Equal(t, NewRange(-1, 12), Expand(r, 2))
+	assert.Equal(t, NewRange(3, 8), Expand(r, -2))
+	assert.Equal(t, NewRange[uint](0, 6), Expand(NewRange[uint](0, 5), 1))
+	assert.Equal(t, NewRange[int8](-128, 127), Expand(NewRange[int8](-100, 100), 50))
+	assert.Equal(t, NewRange[int8](127, -128), Expand(NewRange[int8](100, -100), -50))
+
+	s := NewRange("a", "m")
+	assert.True(t, s.Contains("k"))
+	assert.Equal(t, "m", s.Clamp("z"))
+}
+
+func TestSplit(t *testing.T) {
+	assert.Equal(
+		t,
+		[]Range[int]{NewRange(0, 3), NewRange(4, 6), NewRange(7, 9)},
+		Split(NewRange(0, 9), 3),
+	)
+	assert.Equal(
+		t,
+		[]Range[int]{NewRange(1, 1), NewRange(2, 2)},
+		Split(NewRange(1, 2), 5),
+	)
+	assert.Equal(
+		t,
+		[]Range[uint8]{NewRange[uint8](0, 127), NewRange[uint8](128, 255)},
+		Split(NewRange[uint8](0, 255), 2),
+	)
+	assert.Equal(
+		t,
+		[]Range[float64]{NewHalfOpenRange(0.0, 0.5), NewRange(0.5, 1.0)},
+		Split(NewRange(0.0, 1.0), 2),
+	)
+	assert.Equal(
+		t,
+		[]Range[int]{NewRange(1, 4), NewRange(5, 8)},
+		Split(NewRangeWithBounds(0, Exclusive, 9, Exclusive), 2),
+	)
+	assert.Equal(
+		t,
+		[]Range[int8]{NewRange[int8](-128, -1), NewRange[int8](0, 127)},
+		Split(NewRange[int8](-128, 127), 2),
+	)
+	assert.Equal(
+		t,
+		[]Range[int64]{
+			NewRange[int64](math.MinInt64, math.MinInt64/2-1),
+			NewRange[int64](math.MinInt64/2, -1),
+			NewRange[int64](0, math.MaxInt64/2),
+			NewRange[int64](math.MaxInt64/2+1, math.MaxInt64),
+		},
+		Split(NewRange[int64](math.MinInt64, math.MaxInt64), 4),
+	)
+	assert.Len(t, Split(NewRange[uint64](0, math.MaxUint64), 3), 3)
+	assert.Nil(t, Split(NewRange(0, 9), 0))
+	assert.Nil(t, Split(AtLeast(0), 2))
+}
+
+func TestRange_Bounds(t *testing.T) {
+	type Test struct {
+		r   Range[int]
+		in  []int
+		out []int
+	}
+
+	tests := map[string]Test{
+		"closed":       {r: NewRange(1, 10), in: []int{1, 5, 10}, out: []int{0, 11}},
+		"half-open":    {r: NewHalfOpenRange(1, 10), in: []int{1, 9}, out: []int{0, 10}},
+		"open":         {r: NewOpenRange(1, 10), in: []int{2, 9}, out: []int{1, 10}},
+		"at least":     {r: AtLeast(5), in: []int{5, 1000}, out: []int{4}},
+		"greater than": {r: GreaterThan(5), in: []int{6}, out: []int{5}},
+		"at most":      {r: AtMost(5), in: []int{-1000, 5}, out: []int{6}},
+		"less than":    {r: LessThan(5), in: []int{4}, out: []int{5}},
+		"all":          {r: All[int](), in: []int{-1000, 0, 1000}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range test.in {
+				assert.True(t, test.r.Contains(v), v)
+			}
+			for _, v := range test.out {
+				assert.False(t, test.r.Contains(v), v)
+			}
+		})
+	}
+
+	assert.True(t, NewHalfOpenRange(1, 1).IsEmpty())
+	assert.False(t, NewRange(1, 1).IsEmpty())
+	assert.False(t, AtMost(1).IsEmpty())
+	assert.True(t, AtLeast(10).IsValid())
+
+	assert.False(t, NewHalfOpenRange(1, 5).Overlaps(NewRange(5, 10)))
+	assert.True(t, NewRange(1, 5).Overlaps(NewRange(5, 10)))
+	assert.True(t, AtMost(5).Overlaps(AtLeast(5)))
+	assert.False(t, LessThan(5).Overlaps(AtLeast(5)))
+
+	r, ok := NewRange(1, 10).Intersect(GreaterThan(5))
+	assert.True(t, ok)
+	assert.Equal(t, NewRangeWithBounds(5, Exclusive, 10, Inclusive), r)
+
+	r, ok = NewHalfOpenRange(1, 5).Union(NewHalfOpenRange(5, 10))
+	assert.True(t, ok)
+	assert.Equal(t, NewHalfOpenRange(1, 10), r)
+
+	_, ok = NewOpenRange(1, 5).Union(NewOpenRange(5, 10))
+	assert.False(t, ok)
+
+	r, ok = LessThan(5).Union(NewRange(3, 7))
+	assert.True(t, ok)
+	assert.Equal(t, AtMost(7), r)
+
+	assert.Equal(t, 5, GreaterThan(5).Clamp(1))
+	assert.Equal(t, 100, AtLeast(5).Clamp(100))
+	assert.Equal(t, 0, Span(AtLeast(5)))
+	assert.Equal(t, AtLeast(3), Expand(AtLeast(5), 2))
+}
+
+func TestParse(t *testing.T) {
+	type Test struct {
+		src string
+		dst Range[int]
+		err bool
+	}
+
+	tests := map[string]Test{
+		"closed":      {src: "[1,10]", dst: NewRange(1, 10)},
+		"half-open":   {src: "[1,10)", dst: NewHalfOpenRange(1, 10)},
+		"open":        {src: " ( 1 , 10 ) ", dst: NewOpenRange(1, 10)},
+		"at most":     {src: "(,5]", dst: AtMost(5)},
+		"greater":     {src: "(3,)", dst: GreaterThan(3)},
+		"all":         {src: "(,)", dst: All[int]()},
+		"negative":    {src: "[-5,-1]", dst: NewRange(-5, -1)},
+		"no brackets": {src: "1,10", err: true},
+		"no comma":    {src: "[1]", err: true},
+		"many commas": {src: "[1,2,3]", err: true},
+		"garbage":     {src: "[a,10]", err: true},
+		"trailing":    {src: "[1 2,10]", err: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := Parse[int](test.src)
+			if test.err {
+				assert.ErrorIs(t, err, ErrSyntax)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, test.dst, r)
+			}
+		})
+	}
+
+	for _, src := range []string{"[1,10]", "[1,10)", "(1,10)", "(,5]", "(3,)", "(,)"} {
+		assert.Equal(t, src, MustParse[int](src).String())
+	}
+
+	f, err := Parse[float64]("[0.5,2.5)")
+	assert.NoError(t, err)
+	assert.Equal(t, NewHalfOpenRange(0.5, 2.5), f)
+
+	var r Range[int]
+	assert.NoError(t, r.UnmarshalText([]byte("[1,10)")))
+	assert.Equal(t, NewHalfOpenRange(1, 10), r)
+	text, err := AtLeast(7).MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "[7,)", string(text))
+}
+
+func TestRange_JSON(t *testing.T) {
+	data, err := json.Marshal(NewRange(1, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Min":1,"Max":5}`, string(data))
+
+	data, err = json.Marshal(NewHalfOpenRange(1, 10))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Min":1,"Max":10,"MaxBound":"exclusive"}`, string(data))
+
+	var r Range[int]
+	assert.NoError(t, json.Unmarshal(data, &r))
+	assert.Equal(t, NewHalfOpenRange(1, 10), r)
+
+	var legacy Range[int]
+	assert.NoError(t, json.Unmarshal([]byte(`{"Min":1,"Max":5}`), &legacy))
+	assert.Equal(t, NewRange(1, 5), legacy)
+
+	// Interval notation is accepted too
+	assert.NoError(t, json.Unmarshal([]byte(`"(,5]"`), &r))
+	assert.Equal(t, AtMost(5), r)
+
+	assert.Error(t, json.Unmarshal([]byte(`"[1;5]"`), &r))
+	assert.Error(t, json.Unmarshal([]byte(`{"Min":1,"Max":5,"MinBound":"open"}`), &r))
+	assert.Error(t, json.Unmarshal([]byte(`15`), &r))
+}
@@ -0,0 +1,175 @@
+package ranges
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/adverax/core"
+	"strings"
+)
+
+// ErrSyntax is returned, when string is not valid range.
+var ErrSyntax = errors.New("ranges: invalid syntax")
+
+// String returns textual form of the range in interval notation.
+// Unbounded side has no value: "[1,10)", "(,5]", "(3,)".
+func (r Range[T]) String() string {
+	var sb strings.Builder
+	if r.MinBound == Inclusive {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+	if r.MinBound != Unbounded {
+		sb.WriteString(fmt.Sprint(r.Min))
+	}
+	sb.WriteByte(',')
+	if r.MaxBound != Unbounded {
+		sb.WriteString(fmt.Sprint(r.Max))
+	}
+	if r.MaxBound == Inclusive {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+// MarshalText encodes range in interval notation (see String).
+// JSON uses object form (see MarshalJSON).
+func (r Range[T]) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes range with Parse.
+func (r *Range[T]) UnmarshalText(text []byte) error {
+	res, err := Parse[T](string(text))
+	if err != nil {
+		return err
+	}
+	*r = res
+	return nil
+}
+
+// MarshalJSON encodes range as object {"Min":1,"Max":5}.
+// Bounds are added only if they are not inclusive: {"Min":1,"Max":5,"MaxBound":"exclusive"}.
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Min      T
+		Max      T
+		MinBound Bound `json:",omitempty"`
+		MaxBound Bound `json:",omitempty"`
+	}(r))
+}
+
+// UnmarshalJSON decodes range from object (see MarshalJSON) or from string in interval notation.
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) != 0 && data[0] == '{' {
+		var obj struct {
+			Min      T
+			Max      T
+			MinBound Bound
+			MaxBound Bound
+		}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		*r = Range[T](obj)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(s))
+}
+
+// MarshalText encodes bound by its name.
+func (that Bound) MarshalText() ([]byte, error) {
+	if that < Inclusive || that > Unbounded {
+		return nil, fmt.Errorf("ranges: invalid bound %d", int(that))
+	}
+	return []byte(that.String()), nil
+}
+
+// UnmarshalText decodes bound by its name.
+func (that *Bound) UnmarshalText(text []byte) error {
+	for _, b := range []Bound{Inclusive, Exclusive, Unbounded} {
+		if string(text) == b.String() {
+			*that = b
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: bound %q", ErrSyntax, text)
+}
+
+// Parse converts textual form of the range in interval notation into Range.
+// Brackets define bounds: "[" and "]" are inclusive, "(" and ")" are exclusive,
+// omitted value means unbounded side. Values are parsed by fmt.Sscan, so they can not contain spaces and commas.
+// Example: Parse[int]("[1,10)"), Parse[float64]("(,5]").
+func Parse[T core.Ordered](s string) (Range[T], error) {
+	var res Range[T]
+
+	text := strings.TrimSpace(s)
+	if len(text) < 3 {
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	switch text[0] {
+	case '[':
+		res.MinBound = Inclusive
+	case '(':
+		res.MinBound = Exclusive
+	default:
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	switch text[len(text)-1] {
+	case ']':
+		res.MaxBound = Inclusive
+	case ')':
+		res.MaxBound = Exclusive
+	default:
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	lo, hi, ok := strings.Cut(text[1:len(text)-1], ",")
+	if !ok || strings.Contains(hi, ",") {
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	var err error
+	if lo = strings.TrimSpace(lo); lo == "" {
+		res.MinBound = Unbounded
+	} else if res.Min, err = parseValue[T](lo); err != nil {
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	if hi = strings.TrimSpace(hi); hi == "" {
+		res.MaxBound = Unbounded
+	} else if res.Max, err = parseValue[T](hi); err != nil {
+		return res, fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+	return res, nil
+}
+
+// MustParse is like Parse, but panics on error.
+func MustParse[T core.Ordered](s string) Range[T] {
+	res, err := Parse[T](s)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func parseValue[T core.Ordered](s string) (res T, err error) {
+	var rest string
+	n, _ := fmt.Sscan(s, &res, &rest)
+	if n != 1 {
+		return res, ErrSyntax
+	}
+	return res, nil
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/adverax/types/natural"
+	"time"
+)
+
+// Reader is helper for batch reading properties from the getter.
+// It collects all errors instead of stopping at the first one.
+// Example:
+//
+//	r := NewReader(getter)
+//	port := r.RequireInteger(ctx, "port")
+//	host := r.String(ctx, "host", "localhost")
+//	if err := r.Error(); err != nil {
+//		return err
+//	}
+type Reader struct {
+	getter Getter
+	errors []error
+}
+
+// Errors returns list of all collected errors.
+func (that *Reader) Errors() []error {
+	return that.errors
+}
+
+// Error returns joined error or nil, if all properties were read successfully.
+func (that *Reader) Error() error {
+	return errors.Join(that.errors...)
+}
+
+func (that *Reader) Boolean(ctx context.Context, name string, defVal bool) bool {
+	return Read(ctx, that, Type.Boolean, name, defVal)
+}
+
+func (that *Reader) String(ctx context.Context, name string, defVal string) string {
+	return Read(ctx, that, Type.String, name, defVal)
+}
+
+func (that *Reader) Integer(ctx context.Context, name string, defVal int64) int64 {
+	return Read(ctx, that, Type.Integer, name, defVal)
+}
+
+func (that *Reader) Float(ctx context.Context, name string, defVal float64) float64 {
+	return Read(ctx, that, Type.Float, name, defVal)
+}
+
+func (that *Reader) Duration(ctx context.Context, name string, defVal time.Duration) time.Duration {
+	return Read(ctx, that, Type.Duration, name, defVal)
+}
+
+func (that *Reader) Json(ctx context.Context, name string, defVal json.RawMessage) json.RawMessage {
+	return Read(ctx, that, Type.Json, name, defVal)
+}
+
+func (that *Reader) Fraction(ctx context.Context, name string, defVal natural.Value) natural.Value {
+	return Read(ctx, that, Type.Fraction, name, defVal)
+}
+
+func (that *Reader) Decimal(ctx context.Context, name string, defVal natural.Decimal) natural.Decimal {
+	return Read(ctx, that, Type.Decimal, name, defVal)
+}
+
+func (that *Reader) RequireBoolean(ctx context.Context, name string) bool {
+	return ReadRequired(ctx, that, Type.Boolean, name)
+}
+
+func (that *Reader) RequireString(ctx context.Context, name string) string {
+	return ReadRequired(ctx, that, Type.String, name)
+}
+
+func (that *Reader) RequireInteger(ctx context.Context, name string) int64 {
+	return ReadRequired(ctx, that, Type.Integer, name)
+}
+
+func (that *Reader) RequireFloat(ctx context.Context, name string) float64 {
+	return ReadRequired(ctx, that, Type.Float, name)
+}
+
+func (that *Reader) RequireDuration(ctx context.Context, name string) time.Duration {
+	return ReadRequired(ctx, that, Type.Duration, name)
+}
+
+func (that *Reader) RequireJson(ctx context.Context, name string) json.RawMessage {
+	return ReadRequired(ctx, that, Type.Json, name)
+}
+
+func (that *Reader) RequireFraction(ctx context.Context, name string) natural.Value {
+	return ReadRequired(ctx, that, Type.Fraction, name)
+}
+
+func (that *Reader) RequireDecimal(ctx context.Context, name string) natural.Decimal {
+	return ReadRequired(ctx, that, Type.Decimal, name)
+}
+
+func (that *Reader) addError(err error) {
+	that.errors = append(that.errors, err)
+}
+
+// NewReader is constructor for creating Reader.
+func NewReader(getter Getter) *Reader {
+	return &Reader{getter: getter}
+}
+
+// Read is helper for read optional property by custom typer.
+// On failure error is collected by reader and default value is returned.
+func Read[T any](ctx context.Context, reader *Reader, typer Typer[T], name string, defVal T) T {
+	res, err := typer.Get(ctx, reader.getter, name, defVal)
+	if err != nil {
+		reader.addError(err)
+		return defVal
+	}
+	return res
+}
+
+// ReadRequired is helper for read required property by custom typer.
+// On failure error is collected by reader and zero value is returned.
+func ReadRequired[T any](ctx context.Context, reader *Reader, typer Typer[T], name string) T {
+	res, err := Require(ctx, typer, reader.getter, name)
+	if err != nil {
+		reader.addError(err)
+		var zero T
+		return zero
+	}
+	return res
+}
@@ -0,0 +1,40 @@
+package types
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	ctx := context.Background()
+	r := NewReader(testGetter{"host": "localhost", "port": "abc"})
+
+	assert.Equal(t, "localhost", r.RequireString(ctx, "host"))
+	assert.Equal(t, int64(0), r.RequireInteger(ctx, "port"))
+	assert.Equal(t, int64(0), r.RequireInteger(ctx, "timeout"))
+	assert.Equal(t, int64(5), r.Integer(ctx, "retries", 5))
+
+	assert.Len(t, r.Errors(), 2)
+	var missing *MissingPropertyError
+	assert.ErrorAs(t, r.Error(), &missing)
+	assert.Equal(t, "timeout", missing.Name)
+}
+
+func TestRequire_PlainTyper(t *testing.T) {
+	ctx := context.Background()
+	getter := testGetter{"host": "localhost"}
+
+	// Wrapper hides Require of the underlying typer
+	var typer Typer[string] = &struct{ Typer[string] }{Type.String}
+	_, ok := typer.(RequiredTyper[string])
+	assert.False(t, ok)
+
+	host, err := Require(ctx, typer, getter, "host")
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", host)
+
+	_, err = Require(ctx, typer, getter, "port")
+	var missing *MissingPropertyError
+	assert.ErrorAs(t, err, &missing)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/adverax/types/convert"
+	"github.com/adverax/types/natural"
 	"time"
 )
 
@@ -24,6 +25,10 @@ func (that *StringType) Get(ctx context.Context, getter Getter, name string, def
 	return GetStringProperty(ctx, getter, name, defVal)
 }
 
+func (that *StringType) Require(ctx context.Context, getter Getter, name string) (res string, err error) {
+	return requireProperty(ctx, getter, name, "string", that.TryCast)
+}
+
 func (that *StringType) TryCast(value interface{}) (string, bool) {
 	return convert.ConvertToString(value)
 }
@@ -61,6 +66,10 @@ func (that *IntegerType) Get(ctx context.Context, getter Getter, name string, de
 	return GetIntegerProperty(ctx, getter, name, defVal)
 }
 
+func (that *IntegerType) Require(ctx context.Context, getter Getter, name string) (res int64, err error) {
+	return requireProperty(ctx, getter, name, "integer", that.TryCast)
+}
+
 func (that *IntegerType) TryCast(value interface{}) (int64, bool) {
 	return convert.ConvertToInt64(value)
 }
@@ -90,6 +99,10 @@ func (that *FloatType) Get(ctx context.Context, getter Getter, name string, defV
 	return GetFloatProperty(ctx, getter, name, defVal)
 }
 
+func (that *FloatType) Require(ctx context.Context, getter Getter, name string) (res float64, err error) {
+	return requireProperty(ctx, getter, name, "float", that.TryCast)
+}
+
 func (that *FloatType) TryCast(value interface{}) (float64, bool) {
 	return convert.ConvertToFloat64(value)
 }
@@ -117,6 +130,10 @@ func (that *BooleanType) Get(ctx context.Context, getter Getter, name string, de
 	return GetBooleanProperty(ctx, getter, name, defVal)
 }
 
+func (that *BooleanType) Require(ctx context.Context, getter Getter, name string) (res bool, err error) {
+	return requireProperty(ctx, getter, name, "boolean", that.TryCast)
+}
+
 func (that *BooleanType) TryCast(value interface{}) (bool, bool) {
 	return convert.ConvertToBoolean(value)
 }
@@ -144,6 +161,10 @@ func (that *DurationType) Get(ctx context.Context, getter Getter, name string, d
 	return GetDurationProperty(ctx, getter, name, defVal)
 }
 
+func (that *DurationType) Require(ctx context.Context, getter Getter, name string) (res time.Duration, err error) {
+	return requireProperty(ctx, getter, name, "duration", that.TryCast)
+}
+
 func (that *DurationType) TryCast(value interface{}) (time.Duration, bool) {
 	return convert.ConvertToDuration(value)
 }
@@ -172,6 +193,10 @@ func (that *JsonType) Get(ctx context.Context, getter Getter, name string, defVa
 	return GetJsonProperty(ctx, getter, name, defVal)
 }
 
+func (that *JsonType) Require(ctx context.Context, getter Getter, name string) (res json.RawMessage, err error) {
+	return requireProperty(ctx, getter, name, "json", that.TryCast)
+}
+
 func (that *JsonType) TryCast(value interface{}) (json.RawMessage, bool) {
 	return convert.ConvertToJson(value)
 }
@@ -182,3 +207,84 @@ func (that *JsonType) Cast(v interface{}, defaults json.RawMessage) json.RawMess
 	}
 	return defaults
 }
+
+// DefaultFractionEpsilon is precision of conversion floats into natural fractions.
+const DefaultFractionEpsilon = 1e-6
+
+// FractionType is typer for natural fractions.
+// Floats are converted by natural.NewFromFloat with precision Epsilon
+// (exactly by their decimal representation, if Epsilon is zero).
+type FractionType struct {
+	Epsilon float64
+}
+
+func (that *FractionType) Is(value interface{}) bool {
+	switch value.(type) {
+	case natural.Value:
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (that *FractionType) Get(ctx context.Context, getter Getter, name string, defVal natural.Value) (res natural.Value, err error) {
+	return getProperty(ctx, getter, name, defVal, "fraction", that.TryCast)
+}
+
+func (that *FractionType) Require(ctx context.Context, getter Getter, name string) (res natural.Value, err error) {
+	return requireProperty(ctx, getter, name, "fraction", that.TryCast)
+}
+
+func (that *FractionType) TryCast(value interface{}) (natural.Value, bool) {
+	if that.Epsilon > 0 {
+		switch v := value.(type) {
+		case float32:
+			res := natural.NewFromFloat(float64(v), that.Epsilon)
+			return res, !res.IsNaN()
+		case float64:
+			res := natural.NewFromFloat(v, that.Epsilon)
+			return res, !res.IsNaN()
+		}
+	}
+	return convert.ConvertToNatural(value)
+}
+
+func (that *FractionType) Cast(v interface{}, defaults natural.Value) natural.Value {
+	if vv, ok := that.TryCast(v); ok {
+		return vv
+	}
+	return defaults
+}
+
+// DecimalType is typer for exact decimals.
+type DecimalType struct{}
+
+func (that *DecimalType) Is(value interface{}) bool {
+	switch value.(type) {
+	case natural.Decimal:
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (that *DecimalType) Get(ctx context.Context, getter Getter, name string, defVal natural.Decimal) (res natural.Decimal, err error) {
+	return getProperty(ctx, getter, name, defVal, "decimal", that.TryCast)
+}
+
+func (that *DecimalType) Require(ctx context.Context, getter Getter, name string) (res natural.Decimal, err error) {
+	return requireProperty(ctx, getter, name, "decimal", that.TryCast)
+}
+
+func (that *DecimalType) TryCast(value interface{}) (natural.Decimal, bool) {
+	return convert.ConvertToDecimal(value)
+}
+
+func (that *DecimalType) Cast(v interface{}, defaults natural.Decimal) natural.Decimal {
+	if vv, ok := that.TryCast(v); ok {
+		return vv
+	}
+	return defaults
+}
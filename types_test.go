@@ -0,0 +1,139 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/adverax/types/natural"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFractionType(t *testing.T) {
+	type Test struct {
+		src interface{}
+		dst natural.Value
+		ok  bool
+	}
+
+	tests := map[string]Test{
+		"fraction": {
+			src: "16/9",
+			dst: natural.Value{Num: 16, Div: 9},
+			ok:  true,
+		},
+		"mixed number": {
+			src: "1 1/2",
+			dst: natural.Value{Num: 3, Div: 2},
+			ok:  true,
+		},
+		"integer": {
+			src: 48000,
+			dst: natural.Value{Num: 48000, Div: 1},
+			ok:  true,
+		},
+		"json.Number": {
+			src: json.Number("0.25"),
+			dst: natural.Value{Num: 1, Div: 4},
+			ok:  true,
+		},
+		"float": {
+			src: 1.2,
+			dst: natural.Value{Num: 6, Div: 5},
+			ok:  true,
+		},
+		"natural": {
+			src: natural.Value{Num: 2, Div: 4},
+			dst: natural.Value{Num: 1, Div: 2},
+			ok:  true,
+		},
+		"invalid string": {
+			src: "16:9",
+			ok:  false,
+		},
+		"zero denominator": {
+			src: "1/0",
+			ok:  false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := Type.Fraction.TryCast(test.src)
+			if assert.Equal(t, test.ok, ok) && ok {
+				assert.Equal(t, test.dst, actual)
+			}
+		})
+	}
+}
+
+func TestFractionType_Epsilon(t *testing.T) {
+	exact := &FractionType{}
+	v, ok := exact.TryCast(0.2)
+	assert.True(t, ok)
+	assert.Equal(t, natural.Value{Num: 1, Div: 5}, v)
+
+	rough := &FractionType{Epsilon: 0.01}
+	v, ok = rough.TryCast(0.3334)
+	assert.True(t, ok)
+	assert.Equal(t, natural.Value{Num: 1, Div: 3}, v)
+
+	v, err := rough.Get(context.Background(), testGetter{"ratio": 0.3334}, "ratio", natural.NaN)
+	assert.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: 1, Div: 3}, v)
+}
+
+func TestGetFractionProperty(t *testing.T) {
+	ctx := context.Background()
+	getter := testGetter{"aspect": "16/9", "rate": "bad"}
+	def := natural.Value{Num: 4, Div: 3}
+
+	v, err := GetFractionProperty(ctx, getter, "aspect", def)
+	assert.NoError(t, err)
+	assert.Equal(t, natural.Value{Num: 16, Div: 9}, v)
+
+	v, err = GetFractionProperty(ctx, getter, "missing", def)
+	assert.NoError(t, err)
+	assert.Equal(t, def, v)
+
+	_, err = GetFractionProperty(ctx, getter, "rate", def)
+	assert.Error(t, err)
+
+	_, err = Require(ctx, Type.Fraction, getter, "missing")
+	var missing *MissingPropertyError
+	assert.ErrorAs(t, err, &missing)
+}
+
+func TestDecimalType(t *testing.T) {
+	type Test struct {
+		src interface{}
+		dst string
+		ok  bool
+	}
+
+	tests := map[string]Test{
+		"string":      {src: "19.90", dst: "19.90", ok: true},
+		"json.Number": {src: json.Number("12345678901234567890.12"), dst: "12345678901234567890.12", ok: true},
+		"integer":     {src: 42, dst: "42", ok: true},
+		"float":       {src: 0.1, dst: "0.1", ok: true},
+		"invalid":     {src: "1/2", ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual, ok := Type.Decimal.TryCast(test.src)
+			if assert.Equal(t, test.ok, ok) && ok {
+				assert.Equal(t, test.dst, actual.String())
+			}
+		})
+	}
+
+	ctx := context.Background()
+	getter := testGetter{"price": json.Number("0.30")}
+	v, err := GetDecimalProperty(ctx, getter, "price", natural.Decimal{})
+	assert.NoError(t, err)
+	assert.Equal(t, "0.30", v.String())
+
+	_, err = Require(ctx, Type.Decimal, getter, "missing")
+	var missing *MissingPropertyError
+	assert.ErrorAs(t, err, &missing)
+}
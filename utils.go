@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/adverax/types/natural"
 	"time"
 )
 
@@ -153,3 +154,88 @@ func GetJsonProperty(
 
 	return nil, fmt.Errorf("can not convert value %v into duration with key %q", val, name)
 }
+
+// GetFractionProperty is helper for get natural fraction property from the getter
+func GetFractionProperty(
+	ctx context.Context,
+	getter Getter,
+	name string,
+	defVal natural.Value,
+) (res natural.Value, err error) {
+	return getProperty(ctx, getter, name, defVal, "fraction", Type.Fraction.TryCast)
+}
+
+// GetDecimalProperty is helper for get decimal property from the getter
+func GetDecimalProperty(
+	ctx context.Context,
+	getter Getter,
+	name string,
+	defVal natural.Decimal,
+) (res natural.Decimal, err error) {
+	return getProperty(ctx, getter, name, defVal, "decimal", Type.Decimal.TryCast)
+}
+
+// Require is helper for get mandatory property from the getter by the typer.
+// Missing property is reported with MissingPropertyError.
+func Require[T any](
+	ctx context.Context,
+	typer Typer[T],
+	getter Getter,
+	name string,
+) (res T, err error) {
+	if rt, ok := typer.(RequiredTyper[T]); ok {
+		return rt.Require(ctx, getter, name)
+	}
+	return requireProperty(ctx, getter, name, "value", typer.TryCast)
+}
+
+// getProperty is helper for get optional property from the getter
+func getProperty[T any](
+	ctx context.Context,
+	getter Getter,
+	name string,
+	defVal T,
+	kind string,
+	cast func(value interface{}) (T, bool),
+) (res T, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, GetErrNoMatch()) {
+			return defVal, nil
+		}
+		return
+	}
+	if val == nil {
+		return defVal, nil
+	}
+	res, ok := cast(val)
+	if ok {
+		return
+	}
+	return res, fmt.Errorf("can not convert value %v into %s with key %q", val, kind, name)
+}
+
+// requireProperty is helper for get mandatory property from the getter
+func requireProperty[T any](
+	ctx context.Context,
+	getter Getter,
+	name string,
+	kind string,
+	cast func(value interface{}) (T, bool),
+) (res T, err error) {
+	val, err := getter.GetProperty(ctx, name)
+	if err != nil {
+		if errors.Is(err, GetErrNoMatch()) {
+			return res, &MissingPropertyError{Name: name}
+		}
+		return
+	}
+	if val == nil {
+		return res, &MissingPropertyError{Name: name}
+	}
+	res, ok := cast(val)
+	if ok {
+		return
+	}
+	return res, fmt.Errorf("can not convert value %v into %s with key %q", val, kind, name)
+}